@@ -3,7 +3,6 @@ package engine
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"math"
 	"os"
 	"strconv"
@@ -13,30 +12,44 @@ import (
 
 type CaseData struct {
     Case      int  	  `json:"case"`
-    Degree    float64 `json:"degree"`
+    Degree    int     `json:"degree"`
     Iteration int     `json:"iteration"`
     Time      float64 `json:"time"`
     MRE       float64 `json:"mre"`
+    Refine    InvSqrtRefine `json:"refine"`
+    Pieces    int     `json:"pieces,omitempty"` // Piecewise sub-intervals, 0 for a single polynomial
 }
 
 type LevelData map[string]CaseData
 
-func Get_deg_and_iter(level_int int, fast bool) (float64, int, int){
-	cs, deg, iter := 0, 0.0, 0
+// OptimizerProfile is the path of the optimizer output read by Get_deg_and_iter and HEDAP. The
+// default is relative to the experiment directories under examples.
+var OptimizerProfile = "../../optimizer/result/lattigo_optimizer.json"
 
-	data, err := os.ReadFile("../../optimizer/result/lattigo_optimizer.json")
+func Get_deg_and_iter(level_int int, fast bool) (int, int, int, InvSqrtRefine, error) {
+	chosen, err := getCase(level_int, fast)
 	if err != nil {
-		log.Fatal(err)
+		return 0, 0, 0, NewtonRefine, err
+	}
+	return chosen.Degree, chosen.Iteration, chosen.Case, chosen.Refine, nil
+}
+
+// getCase returns the configuration of OptimizerProfile for the input level, the zero CaseData
+// when there is none.
+func getCase(level_int int, fast bool) (CaseData, error) {
+	data, err := os.ReadFile(OptimizerProfile)
+	if err != nil {
+		return CaseData{}, fmt.Errorf("read optimizer profile: %w", err)
 	}
 
 	var result map[string]LevelData
 	if err := json.Unmarshal(data, &result); err != nil {
-		log.Fatal(err)
+		return CaseData{}, fmt.Errorf("parse optimizer profile %s: %w", OptimizerProfile, err)
 	}
 
+	var chosen CaseData
 	level := strconv.Itoa(level_int + 1)
 	if levelCases, ok := result[level]; ok {
-		var chosen CaseData
 		found := false
 
 		if fast {
@@ -57,20 +70,11 @@ func Get_deg_and_iter(level_int int, fast bool) (float64, int, int){
 				break
 			}
 		}
-
-		deg = chosen.Degree
-		iter = chosen.Iteration
-		cs = chosen.Case
 	}
-	return deg, iter, cs
+	return chosen, nil
 }
 
-func (e *HEEngine) ZScoreNorm(ct *HEData, B float64, fast bool) (*HEData, error) {
-	const (
-		chebyshevDegree = 2 // Degree for initial Chebyshev approximation
-		newtonScale     = 2 // Scaling for Newton method
-	)
-
+func (e *HEEngine) ZScoreNorm(ct *HEData, B float64, s InvSqrtStrategy) (*HEData, error) {
 	// Step 1: Compute mean μ
 	mean, err := e.Mean(ct)
 	if err != nil {
@@ -84,10 +88,10 @@ func (e *HEEngine) ZScoreNorm(ct *HEData, B float64, fast bool) (*HEData, error)
 	}
 
 	
-	// Step 3: Compute inverse of standard deviation 1/σ using invSqrt
-	invSigmaRefined, err := e.computeInvStd(ct, fast, newtonScale, B)
+	// Step 3: Compute inverse of standard deviation 1/σ with the strategy
+	invSigmaRefined, err := s.InvStd(e, ct, B)
 	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
+		return nil, fmt.Errorf("InvStd: %w", err)
 	}
 
 	// Step 4: Extend 1/σ to all slots for element-wise multiplication
@@ -105,157 +109,19 @@ func (e *HEEngine) ZScoreNorm(ct *HEData, B float64, fast bool) (*HEData, error)
 	return zscore, nil
 }
 
-func (e *HEEngine) Kurtosis(ct *HEData, B float64, fast bool) (*HEData, error) {
-	const (
-		chebyshevDegree = 2
-		newtonScale     = 2
-		subtractBias    = 3.0 // for excess kurtosis
-	)
-	// Step 1: Compute mean μ
-	mean, err := e.Mean(ct)
-	if err != nil {
-		return nil, fmt.Errorf("mean: %w", err)
-	}
-
-	// Step 2: Centered input: x = X - μ
-	centered, err := e.Sub(ct, mean)
-	if err != nil {
-		return nil, fmt.Errorf("center: %w", err)
-	}
-
-	// Step 3: Compute x² and x⁴
-	x2, err := e.Mult(centered, centered)
-	if err != nil {
-		return nil, fmt.Errorf("x^2: %w", err)
-	}
-
-	x4, err := e.Mult(x2, x2)
-	if err != nil {
-		return nil, fmt.Errorf("x^4: %w", err)
-	}
-
-	// Step 4: Compute E[x⁴] (numerator)
-	numerator, err := e.Mean(x4)
-	if err != nil {
-		return nil, fmt.Errorf("mean of x^4: %w", err)
-	}
-
-	// Step 5: Compute inverse of standard deviation 1/σ using invSqrt
-	invSigmaRefined, err := e.computeInvStd(ct, fast, newtonScale, B)
-	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
-	}
-
-	// Step ６: Compute 1/σ⁴ = (1/σ)² × (1/σ)²
-	invSigma2, err := e.Mult(invSigmaRefined, invSigmaRefined)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ²: %w", err)
-	}
-
-	invSigma4, err := e.Mult(invSigma2, invSigma2)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ⁴: %w", err)
-	}
-
-	// Step 7: Apply inv(σ⁴) to numerator: E[x⁴] × (1/σ⁴)
-	invSigma4Expanded, err := e.extendOneToMulty(invSigma4, len(numerator.Ciphertexts()), numerator.Size())
-	if err != nil {
-		return nil, fmt.Errorf("extendOneToMulty (inv σ⁴): %w", err)
-	}
-
-	if e.IsBTS {
-		invSigma4Expanded, _ = e.DoBootstrap(invSigma4Expanded, 1)
-	}
-
-	kurtosis, err := e.Mult(numerator, invSigma4Expanded)
-	if err != nil {
-		return nil, fmt.Errorf("final multiply: %w", err)
-	}
-
-	// Step 8: Convert to excess kurtosis: K − 3
-	kurtosis, err = e.SubConst(kurtosis, subtractBias)
-	if err != nil {
-		return nil, fmt.Errorf("subtract bias: %w", err)
-	}
-
-	return kurtosis, nil
+// Kurtosis computes the population excess kurtosis E[(X - μ)⁴]/σ⁴ - 3 of ct (see
+// StandardizedMoment and KurtosisEstimate).
+func (e *HEEngine) Kurtosis(ct *HEData, B float64, s InvSqrtStrategy) (*HEData, error) {
+	return e.KurtosisEstimate(ct, B, s, Population)
 }
 
-func (e *HEEngine) Skewness(ct *HEData, B float64, fast bool) (*HEData, error) {
-	const (
-		chebyshevDegree = 2
-		newtonScale     = 2
-	)
-	// Step 1: Compute mean μ
-	mean, err := e.Mean(ct)
-	if err != nil {
-		return nil, fmt.Errorf("compute mean: %w", err)
-	}
-
-	// Step 2: Centered data x = X - μ
-	centered, err := e.Sub(ct, mean)
-	if err != nil {
-		return nil, fmt.Errorf("center data: %w", err)
-	}
-
-	// Step 3: Compute x² and x³
-	x2, err := e.Mult(centered, centered)
-	if err != nil {
-		return nil, fmt.Errorf("compute x^2: %w", err)
-	}
-
-	x3, err := e.Mult(centered, x2)
-	if err != nil {
-		return nil, fmt.Errorf("compute x^3: %w", err)
-	}
-
-	// Step 4: Compute E[x³]
-	numerator, err := e.Mean(x3)
-	if err != nil {
-		return nil, fmt.Errorf("mean of x^3: %w", err)
-	}
-
-	// Step 5: Compute inverse of standard deviation 1/σ using invSqrt
-	invSigmaRefined, err := e.computeInvStd(ct, fast, newtonScale, B)
-	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
-	}
-
-	// Step 6: Compute (1/σ)³ = (1/σ) × (1/σ)²
-	invSigma2, err := e.Mult(invSigmaRefined, invSigmaRefined)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ²: %w", err)
-	}
-
-	invSigma3, err := e.Mult(invSigmaRefined, invSigma2)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ³: %w", err)
-	}
-
-	// Step 7: Final result: E[x³] × (1/σ³)
-	invSigma3Expanded, err := e.extendOneToMulty(invSigma3, len(numerator.Ciphertexts()), numerator.Size())
-	if err != nil {
-		return nil, fmt.Errorf("extendOneToMulty: %w", err)
-	}
-
-	if e.IsBTS {
-		invSigma3Expanded, _ = e.DoBootstrap(invSigma3Expanded, 1)
-	}
-
-	skewness, err := e.Mult(numerator, invSigma3Expanded)
-	if err != nil {
-		return nil, fmt.Errorf("final multiply: %w", err)
-	}
-
-	return skewness, nil
+// Skewness computes the population skewness E[(X - μ)³]/σ³ of ct (see StandardizedMoment and
+// SkewnessEstimate).
+func (e *HEEngine) Skewness(ct *HEData, B float64, s InvSqrtStrategy) (*HEData, error) {
+	return e.SkewnessEstimate(ct, B, s, Population)
 }
 
-func (e *HEEngine) PCorrCoeff(ct1, ct2 *HEData, B float64, fast bool) (*HEData, error) {
-	const (
-		chebyshevDegree = 2
-		newtonScale     = 2
-	)
-
+func (e *HEEngine) PCorrCoeff(ct1, ct2 *HEData, B float64, s InvSqrtStrategy) (*HEData, error) {
 	// Step 1: Compute means
 	meanX, err := e.Mean(ct1)
 	if err != nil {
@@ -286,19 +152,22 @@ func (e *HEEngine) PCorrCoeff(ct1, ct2 *HEData, B float64, fast bool) (*HEData,
 		return nil, fmt.Errorf("mean of x·y: %w", err)
 	}
 
-	// Step 4: Compute inverse std for X
-	invStdX, err := e.computeInvStd(ct1, fast, newtonScale, B)
+	// Step 4: Compute inverse std for X and Y with one inverse square root
+	invStd, err := e.BatchedInvStd([]*HEData{ct1, ct2}, B, s)
 	if err != nil {
-		return nil, fmt.Errorf("computeInvStd (X): %w", err)
+		return nil, fmt.Errorf("BatchedInvStd: %w", err)
 	}
-
-	// Step 5: Compute inverse std for Y
-	invStdY, err := e.computeInvStd(ct2, fast, newtonScale, B)
-	if err != nil {
-		return nil, fmt.Errorf("computeInvStd (Y): %w", err)
+	invStdX, invStdY := invStd[0], invStd[1]
+	if e.IsBTS {
+		if invStdX, err = e.DoBootstrap(invStdX, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σx): %w", err)
+		}
+		if invStdY, err = e.DoBootstrap(invStdY, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σy): %w", err)
+		}
 	}
 
-	// Step 6: Compute PCC = numerator × (1/σx) × (1/σy)
+	// Step 5: Compute PCC = numerator × (1/σx) × (1/σy)
 	denominator, err := e.Mult(invStdX, invStdY)
 	if err != nil {
 		return nil, fmt.Errorf("σx·σy inverse: %w", err)
@@ -317,500 +186,82 @@ func (e *HEEngine) PCorrCoeff(ct1, ct2 *HEData, B float64, fast bool) (*HEData,
 	return pcc, nil
 }
 
-func (e *HEEngine) ZScoreNorm_ppstat(ct *HEData, B float64) (*HEData, error) {
-	const (
-		chebyshevDegree = 2 // Degree for initial Chebyshev approximation
-		newtonIter      = 5 // Iteration count for Newton refinement
-		newtonScale     = 2 // Scaling for Newton method
-		bootstrapDepth  = 3 // Depth used when bootstrapping initial guess
-	)
-
-	// Step 1: Compute mean μ
-	mean, err := e.Mean(ct)
-	if err != nil {
-		return nil, fmt.Errorf("compute mean: %w", err)
-	}
-
-	// Step 2: Approximate variance Var(X)
-	denom := float64(ct.Size()) * B
-	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
-	if err != nil {
-		return nil, fmt.Errorf("compute variance (approx): %w", err)
-	}
-
-	// Step 3: Center data => X - μ
-	centered, err := e.Sub(ct, mean)
-	if err != nil {
-		return nil, fmt.Errorf("center input: %w", err)
-	}
-
-	// Select representative ciphertext for approximation
-	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (variance approx): %w", err)
-	}
-
-	// Step 4: Initial guess for 1/σ using Chebyshev
-
-	// Optional: Bootstrap the initial guess for higher precision
-	if e.IsBTS {
-		varApproxCtxt, err = e.DoBootstrap(varApproxCtxt, 9)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
-		}
-	}
-
-	invSigmaInit, err := e.ChebyshevInvSqrt(varApproxCtxt, chebyshevDegree, B*B)
-	if err != nil {
-		return nil, fmt.Errorf("ChebyshevInvSqrt: %w", err)
-	}
-
-	// Optional: Bootstrap the initial guess for higher precision
-	if e.IsBTS {
-		invSigmaInit, err = e.DoBootstrap(invSigmaInit, bootstrapDepth)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
-		}
-	}
-
-	// Step 5: Refine variance and compute Newton-based 1/σ
-	denom = float64(ct.Size()) * math.Sqrt(2)
-	varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom * math.Sqrt(2))
-	if err != nil {
-		return nil, fmt.Errorf("compute refined variance: %w", err)
-	}
-	varRefinedCtxt, err := e.selectOneCtxt(varianceRefined)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (variance refined): %w", err)
-	}
-
-	invSigmaRefined, err := e.HENewtonInv(varRefinedCtxt, invSigmaInit, B, newtonIter, newtonScale)
-	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
-	}
-
-	// Step 6: Extend 1/σ to all slots for element-wise multiplication
-	invSigmaSlots, err := e.extendOneToMulty(invSigmaRefined, len(centered.Ciphertexts()), centered.Size())
-	if err != nil {
-		return nil, fmt.Errorf("extendOneToMulty: %w", err)
-	}
-
-	// Step 7: Final Z-score normalization: Z = (X - μ) × (1/σ)
-	zscore, err := e.Mult(centered, invSigmaSlots)
-	if err != nil {
-		return nil, fmt.Errorf("final multiply: %w", err)
-	}
-
-	return zscore, nil
-}
-
-func (e *HEEngine) Kurtosis_ppstat(ct *HEData, B float64) (*HEData, error) {
-	const (
-		chebyshevDegree = 2
-		newtonIter      = 5
-		newtonScale     = 2
-		bootstrapDepth  = 3
-		subtractBias    = 3.0 // for excess kurtosis
-	)
-
+// StdDev computes the population standard deviation σ as Var[X]·(1/√Var[X]), with the inverse
+// square root of the strategy applied to the variance, so that its relative error is that of 1/σ
+// plus the CKKS noise. The result is replicated in the valid slots of a single ciphertext.
+func (e *HEEngine) StdDev(ct *HEData, B float64, s InvSqrtStrategy) (*HEData, error) {
 	// Step 1: Compute mean μ
 	mean, err := e.Mean(ct)
 	if err != nil {
 		return nil, fmt.Errorf("mean: %w", err)
 	}
 
-	// Step 2: Centered input: x = X - μ
+	// Step 2: Compute Var[X] = E[(X - μ)²]
 	centered, err := e.Sub(ct, mean)
 	if err != nil {
 		return nil, fmt.Errorf("center: %w", err)
 	}
-
-	// Step 3: Compute x² and x⁴
 	x2, err := e.Mult(centered, centered)
 	if err != nil {
 		return nil, fmt.Errorf("x^2: %w", err)
 	}
-
-	x4, err := e.Mult(x2, x2)
-	if err != nil {
-		return nil, fmt.Errorf("x^4: %w", err)
-	}
-
-	// Step 4: Compute E[x⁴] (numerator)
-	numerator, err := e.Mean(x4)
+	variance, err := e.Mean(x2)
 	if err != nil {
-		return nil, fmt.Errorf("mean of x^4: %w", err)
+		return nil, fmt.Errorf("mean of x^2: %w", err)
 	}
-
-	// Step 5: Approximate variance Var(X)
-	denom := float64(ct.Size()) * B
-	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
+	variance, err = e.selectOneCtxt(variance)
 	if err != nil {
-		return nil, fmt.Errorf("variance (approx): %w", err)
+		return nil, fmt.Errorf("selectOneCtxt: %w", err)
 	}
 
-	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
+	// Step 3: Compute inverse of standard deviation 1/σ = 1/√Var[X] with the strategy
+	invSigma, err := s.InvSqrt(e, variance, B*B)
 	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (variance approx): %w", err)
+		return nil, fmt.Errorf("InvSqrt: %w", err)
 	}
-
-	// Step 6: Initial approximation of 1/σ using Chebyshev
-
-	// Optional: Bootstrap the initial guess for higher precision
 	if e.IsBTS {
-		varApproxCtxt, err = e.DoBootstrap(varApproxCtxt, 9)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
+		if invSigma, err = e.DoBootstrap(invSigma, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σ): %w", err)
 		}
 	}
-	invSigmaInit, err := e.ChebyshevInvSqrt(varApproxCtxt, chebyshevDegree, B*B)
-	if err != nil {
-		return nil, fmt.Errorf("ChebyshevInvSqrt: %w", err)
-	}
 
-	if e.IsBTS {
-		invSigmaInit, err = e.DoBootstrap(invSigmaInit, bootstrapDepth)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (Chebyshev init): %w", err)
-		}
-	}
-
-	// Step 7: Refine 1/σ using Newton method
-	denom = float64(ct.Size()) * math.Sqrt(2)
-	varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom * math.Sqrt(2))
-	if err != nil {
-		return nil, fmt.Errorf("compute refined variance: %w", err)
-	}
-	varRefinedCtxt, err := e.selectOneCtxt(varianceRefined)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (variance refined): %w", err)
-	}
-
-	invSigma, err := e.HENewtonInv(varRefinedCtxt, invSigmaInit, B, newtonIter, newtonScale)
-	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
-	}
-
-	// Step 8: Compute 1/σ⁴ = (1/σ)² × (1/σ)²
-	invSigma2, err := e.Mult(invSigma, invSigma)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ²: %w", err)
-	}
-
-	invSigma4, err := e.Mult(invSigma2, invSigma2)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ⁴: %w", err)
-	}
-
-	// Step 9: Apply inv(σ⁴) to numerator: E[x⁴] × (1/σ⁴)
-	invSigma4Expanded, err := e.extendOneToMulty(invSigma4, len(numerator.Ciphertexts()), numerator.Size())
-	if err != nil {
-		return nil, fmt.Errorf("extendOneToMulty (inv σ⁴): %w", err)
-	}
-
-	kurtosis, err := e.Mult(numerator, invSigma4Expanded)
-	if err != nil {
-		return nil, fmt.Errorf("final multiply: %w", err)
-	}
-
-	// Step 10: Convert to excess kurtosis: K − 3
-	kurtosis, err = e.SubConst(kurtosis, subtractBias)
-	if err != nil {
-		return nil, fmt.Errorf("subtract bias: %w", err)
-	}
-
-	return kurtosis, nil
+	// Step 4: σ = Var[X] × (1/σ)
+	return e.Mult(variance, invSigma)
 }
 
-func (e *HEEngine) Skewness_ppstat(ct *HEData, B float64) (*HEData, error) {
-	const (
-		chebyshevDegree = 2
-		newtonIter      = 5
-		newtonScale     = 2
-		bootstrapDepth  = 3
-	)
-
-	// Step 1: Compute mean μ
-	mean, err := e.Mean(ct)
-	if err != nil {
-		return nil, fmt.Errorf("compute mean: %w", err)
-	}
-
-	// Step 2: Centered data x = X - μ
-	centered, err := e.Sub(ct, mean)
-	if err != nil {
-		return nil, fmt.Errorf("center data: %w", err)
-	}
-
-	// Step 3: Compute x² and x³
-	x2, err := e.Mult(centered, centered)
-	if err != nil {
-		return nil, fmt.Errorf("compute x^2: %w", err)
-	}
-
-	x3, err := e.Mult(centered, x2)
-	if err != nil {
-		return nil, fmt.Errorf("compute x^3: %w", err)
-	}
-
-	// Step 4: Compute E[x³]
-	numerator, err := e.Mean(x3)
-	if err != nil {
-		return nil, fmt.Errorf("mean of x^3: %w", err)
-	}
-
-	// Step 5: Approximate variance σ²
-	denom := float64(ct.Size()) * B
-	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
-	if err != nil {
-		return nil, fmt.Errorf("variance (approx): %w", err)
-	}
-
-	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (variance approx): %w", err)
-	}
-
-	// Step 6: Initial approximation of 1/σ using Chebyshev
-	
-	// Optional: Bootstrap the initial guess for higher precision
-	if e.IsBTS {
-		varApproxCtxt, err = e.DoBootstrap(varApproxCtxt, 9)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
-		}
-	}
-	invSigmaInit, err := e.ChebyshevInvSqrt(varApproxCtxt, chebyshevDegree, B*B)
-	if err != nil {
-		return nil, fmt.Errorf("ChebyshevInvSqrt: %w", err)
-	}
-
-	if e.IsBTS {
-		invSigmaInit, err = e.DoBootstrap(invSigmaInit, bootstrapDepth)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (Chebyshev init): %w", err)
-		}
-	}
-
-	// Step 7: Refine inverse std dev using Newton
-	denom = float64(ct.Size()) * math.Sqrt(2)
-	varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom * math.Sqrt(2))
-	if err != nil {
-		return nil, fmt.Errorf("compute refined variance: %w", err)
-	}
-	varRefinedCtxt, err := e.selectOneCtxt(varianceRefined)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (variance refined): %w", err)
-	}
-
-	invSigma, err := e.HENewtonInv(varRefinedCtxt, invSigmaInit, B, newtonIter, newtonScale)
-	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
-	}
-
-	// Step 8: Compute (1/σ)³ = (1/σ) × (1/σ)²
-	invSigma2, err := e.Mult(invSigma, invSigma)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ²: %w", err)
-	}
-
-	invSigma3, err := e.Mult(invSigma, invSigma2)
-	if err != nil {
-		return nil, fmt.Errorf("inv σ³: %w", err)
-	}
-
-	// Step 9: Final result: E[x³] × (1/σ³)
-	invSigma3Expanded, err := e.extendOneToMulty(invSigma3, len(numerator.Ciphertexts()), numerator.Size())
-	if err != nil {
-		return nil, fmt.Errorf("extendOneToMulty: %w", err)
-	}
-
-	skewness, err := e.Mult(numerator, invSigma3Expanded)
-	if err != nil {
-		return nil, fmt.Errorf("final multiply: %w", err)
-	}
-
-	return skewness, nil
-}
-
-func (e *HEEngine) PCorrCoeff_ppstat(ct1, ct2 *HEData, B float64) (*HEData, error) {
-	const (
-		chebyshevDegree = 2
-		newtonIter      = 5
-		newtonScale     = 2
-		bootstrapDepth  = 3
-	)
-
-	// Step 1: Compute means
-	meanX, err := e.Mean(ct1)
-	if err != nil {
-		return nil, fmt.Errorf("mean of ct1: %w", err)
-	}
-	meanY, err := e.Mean(ct2)
-	if err != nil {
-		return nil, fmt.Errorf("mean of ct2: %w", err)
-	}
-
-	// Step 2: Centered data
-	xCentered, err := e.Sub(ct1, meanX)
-	if err != nil {
-		return nil, fmt.Errorf("center ct1: %w", err)
-	}
-	yCentered, err := e.Sub(ct2, meanY)
-	if err != nil {
-		return nil, fmt.Errorf("center ct2: %w", err)
-	}
-
-	// Step 3: Numerator = E[(X - μx)(Y - μy)]
-	mulXY, err := e.Mult(xCentered, yCentered)
+// StandardError computes the standard error of the mean σ/√n, with σ as in StdDev.
+func (e *HEEngine) StandardError(ct *HEData, B float64, s InvSqrtStrategy) (*HEData, error) {
+	sigma, err := e.StdDev(ct, B, s)
 	if err != nil {
-		return nil, fmt.Errorf("x·y: %w", err)
+		return nil, fmt.Errorf("StdDev: %w", err)
 	}
-	numerator, err := e.Mean(mulXY)
-	if err != nil {
-		return nil, fmt.Errorf("mean of x·y: %w", err)
-	}
-
-	// Step 4: Compute inverse std for X
-	invStdX, err := e.computeInvStd_ppstat(ct1, chebyshevDegree, newtonIter, newtonScale, bootstrapDepth, B)
-	if err != nil {
-		return nil, fmt.Errorf("computeInvStd (X): %w", err)
-	}
-
-	// Step 5: Compute inverse std for Y
-	invStdY, err := e.computeInvStd_ppstat(ct2, chebyshevDegree, newtonIter, newtonScale, bootstrapDepth, B)
-	if err != nil {
-		return nil, fmt.Errorf("computeInvStd (Y): %w", err)
-	}
-
-	// Step 6: Compute PCC = numerator × (1/σx) × (1/σy)
-	denominator, err := e.Mult(invStdX, invStdY)
-	if err != nil {
-		return nil, fmt.Errorf("σx·σy inverse: %w", err)
-	}
-
-	denominatorExpanded, err := e.extendOneToMulty(denominator, len(numerator.Ciphertexts()), numerator.Size())
-	if err != nil {
-		return nil, fmt.Errorf("extendOneToMulty: %w", err)
-	}
-
-	pcc, err := e.Mult(numerator, denominatorExpanded)
-	if err != nil {
-		return nil, fmt.Errorf("final multiply: %w", err)
-	}
-
-	return pcc, nil
+	return e.MultConst(sigma, 1/math.Sqrt(float64(ct.Size())))
 }
 
+// coeffVarPrecision is the relative error targeted by the inverse of the mean in CoeffVar.
+const coeffVarPrecision = 1e-6
 
-func (e *HEEngine) computeInvStd_ppstat(ct *HEData, chebDeg, newtonIter, newtonScale, bootstrapDepth int, B float64) (*HEData, error) {
-	denom := float64(ct.Size()) * B
-
-	// Approximate variance
-	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
-	if err != nil {
-		return nil, fmt.Errorf("variance (approx): %w", err)
-	}
-	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (approx): %w", err)
-	}
-
-	// Initial guess for 1/σ
-	
-	// Optional: Bootstrap the initial guess for higher precision
-	if e.IsBTS {
-		varApproxCtxt, err = e.DoBootstrap(varApproxCtxt, 9)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
-		}
-	}
-	invSigmaInit, err := e.ChebyshevInvSqrt(varApproxCtxt, chebDeg, B*B)
-	if err != nil {
-		return nil, fmt.Errorf("ChebyshevInvSqrt: %w", err)
-	}
-	if e.IsBTS {
-		invSigmaInit, err = e.DoBootstrap(invSigmaInit, bootstrapDepth)
-		if err != nil {
-			return nil, fmt.Errorf("bootstrap: %w", err)
-		}
-	}
-
-	// Refined variance
-	denom = float64(ct.Size()) * math.Sqrt(2)
-	varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom * math.Sqrt(2))
-	if err != nil {
-		return nil, fmt.Errorf("compute refined variance: %w", err)
-	}
-	varRefinedCtxt, err := e.selectOneCtxt(varianceRefined)
-	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (refined): %w", err)
-	}
-
-	// Newton refinement
-	invStd, err := e.HENewtonInv(varRefinedCtxt, invSigmaInit, B, newtonIter, newtonScale)
+// CoeffVar computes the coefficient of variation σ/μ, with σ as in StdDev and 1/μ from Inverse.
+// The mean must satisfy meanLo ≤ μ ≤ meanHi with 0 < meanLo < meanHi.
+func (e *HEEngine) CoeffVar(ct *HEData, B, meanLo, meanHi float64, s InvSqrtStrategy) (*HEData, error) {
+	// Step 1: Compute σ
+	sigma, err := e.StdDev(ct, B, s)
 	if err != nil {
-		return nil, fmt.Errorf("HENewtonInv: %w", err)
+		return nil, fmt.Errorf("StdDev: %w", err)
 	}
 
-	return invStd, nil
-}
-
-func (e *HEEngine) computeInvStd(ct *HEData, fast bool, newtonScale int, B float64) (*HEData, error) {
-	
-	deg, iter, cs := Get_deg_and_iter(ct.Level() - 2, fast)
-
-	denom := float64(ct.Size()) * B
-	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
+	// Step 2: Compute mean μ
+	mean, err := e.Mean(ct)
 	if err != nil {
-		return nil, fmt.Errorf("variance (approx): %w", err)
+		return nil, fmt.Errorf("mean: %w", err)
 	}
-	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
+	mean, err = e.selectOneCtxt(mean)
 	if err != nil {
-		return nil, fmt.Errorf("selectOneCtxt (approx): %w", err)
+		return nil, fmt.Errorf("selectOneCtxt: %w", err)
 	}
-	
-	if cs == 1 {
-		if e.IsBTS {
-			varApproxCtxt, err = e.DoBootstrap(varApproxCtxt, e.params.MaxLevel())
-			if err != nil {
-				return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
-			}
-		}
-
-		varRefinedCtxt, err := e.MultConst(varApproxCtxt, (B*B)/2)
-		if err != nil {
-			return nil, fmt.Errorf("variance (refined): %w", err)
-		}
 
-		// Newton refinement
-		invStd, err := e.CryptoInvSqrt(varRefinedCtxt, varApproxCtxt, B*B, deg, iter-1, 2, newtonScale)
-		if err != nil {
-			return nil, fmt.Errorf("HENewtonInv: %w", err)
-		}
-
-		return invStd, nil
-
-	} else {
-
-		denom := float64(ct.Size()) * math.Sqrt(2)
-		varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom * math.Sqrt(2))
-		if err != nil {
-			return nil, fmt.Errorf("variance (refined): %w", err)
-		}
-		varRefinedCtxt, err := e.selectOneCtxt(varianceRefined)
-		if err != nil {
-			return nil, fmt.Errorf("selectOneCtxt (refined): %w", err)
-		}
-
-		// Newton refinement
-		invStd, err := e.CryptoInvSqrt(varRefinedCtxt, varApproxCtxt, B*B, deg, iter-1, 2, newtonScale)
-		if err != nil {
-			return nil, fmt.Errorf("HENewtonInv: %w", err)
-		}
-
-		return invStd, nil
-	}
+	// Step 3: CV = σ × (1/μ)
+	return e.Divide(sigma, mean, meanLo, meanHi, coeffVarPrecision)
 }
 
 func varianceWithCustomDenom(e *HEEngine, ct *HEData, xDenom, xSquareDenom float64) (*HEData, error) {
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestStdDev(t *testing.T) {
+	e := testEngine(t)
+	x := normalData(1, 500, 3, 2)
+	ct := encryptTest(t, e, x)
+
+	sigma, err := e.StdDev(ct, 10, PPStat{})
+	checkScalar(t, "StdDev", decryptTest(t, e, sigma, err), utils.StdDev(x), 1e-4)
+
+	se, err := e.StandardError(ct, 10, PPStat{})
+	checkScalar(t, "StandardError", decryptTest(t, e, se, err), utils.StandardError(x), 1e-4)
+
+	cv, err := e.CoeffVar(ct, 10, 1, 10, PPStat{})
+	_, _, want := utils.CoeffVar(x)
+	checkScalar(t, "CoeffVar", decryptTest(t, e, cv, err), want, 1e-4)
+}
+
+// checkSqrt compares Sqrt on x ⊂ [0, interval] with √x.
+func checkSqrt(t *testing.T, e *HEEngine, name string, s InvSqrtStrategy, x []float64, interval, tol float64) {
+	t.Helper()
+	want := make([]float64, len(x))
+	for i := range want {
+		want[i] = math.Sqrt(x[i])
+	}
+
+	ct, err := e.Encrypt(x, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := e.Sqrt(ct, interval, s)
+	checkClose(t, name+" √x", decryptTest(t, e, root, err), want, tol)
+}
+
+func TestStdDevBTS(t *testing.T) {
+	e := testBTSEngine(t)
+	x := normalData(1, 500, 3, 2)
+	ct := encryptTest(t, e, x)
+
+	sigma, err := e.StdDev(ct, 10, HEDAP{})
+	checkScalar(t, "StdDev", decryptTest(t, e, sigma, err), utils.StdDev(x), 1e-4)
+}
+
+func TestPCorrCoeff(t *testing.T) {
+	e := testEngine(t)
+	data := batchColumns(2, 200)
+	x, y := encryptTest(t, e, data[0]), encryptTest(t, e, data[1])
+	_, want, _ := utils.Correlation(data[0], data[1])
+
+	pcc, err := e.PCorrCoeff(x, y, 10, PPStat{})
+	checkScalar(t, "PPStat r", decryptTest(t, e, pcc, err), want, 1e-5)
+
+	// HEStat loses accuracy in the batch without bootstrapping (see TestBatchedInvStdAccuracy)
+	pcc, err = e.PCorrCoeff(x, y, 10, HEStat{Iter: 10})
+	checkScalar(t, "HEStat r", decryptTest(t, e, pcc, err), want, 2e-4)
+}
+
+func TestSqrt(t *testing.T) {
+	e := testEngine(t)
+	checkSqrt(t, e, "PPStat", PPStat{}, utils.Linspace(5, 100, 64), 100, 1e-3)
+}
+
+func TestSqrtBTS(t *testing.T) {
+	e := testBTSEngine(t)
+	checkSqrt(t, e, "HEDAP", HEDAP{}, utils.Linspace(5, 100, 64), 100, 1e-3)
+}
+
+func TestHEDAPVarianceDepth(t *testing.T) {
+	e := testEngine(t)
+	ct := encryptTest(t, e, normalData(1, 100, 0, 1))
+	variance, err := varianceWithCustomDenom(e, ct, 100*4, 100*16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ct.Level() - variance.Level(); got != hedapVarianceDepth {
+		t.Errorf("variance consumes %d levels, HEDAP.InvStd assumes %d", got, hedapVarianceDepth)
+	}
+}
@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// ANOVAResult holds an encrypted one-way ANOVA F statistic, in the first slot, and its public
+// degrees of freedom k-1 (between groups) and n-k (within groups).
+type ANOVAResult struct {
+	F         *HEData
+	DFBetween int
+	DFWithin  int
+}
+
+// ANOVA computes the one-way ANOVA F statistic of values over the groups given by encrypted 0/1
+// membership masks (see EncryptOneHot), which must partition the values.
+//
+// The values are centered first, so that with the group proportions p_g = n_g/n and the
+// normalized group sums s_g = S_g/n of the centered values the sums of squares divided by n are
+//
+//	SSB/n = Σ s_g²/p_g,  SSW/n = Var[x] - SSB/n,
+//
+// and F = (n-k)/(k-1) · SSB/SSW. Centering avoids computing SSB as the difference of two close
+// quantities when the group means are similar. Each s_g²/p_g is evaluated as (s_g·(1/√p_g))²,
+// with 1/√p_g from the strategy's InvSqrt on p_g ∈ (0, 1], and 1/SSW as the square of
+// 1/√(SSW/n). B bounds the data as in InvSqrtStrategy.InvStd: the population variance must be at
+// most 2B². The accuracy degrades for very small groups and when the within-group variance is
+// small relative to B².
+func (e *HEEngine) ANOVA(values *HEData, groups []*HEData, B float64, s InvSqrtStrategy) (*ANOVAResult, error) {
+	if len(groups) < 2 {
+		return nil, fmt.Errorf("ANOVA needs at least two groups, got %d", len(groups))
+	}
+
+	// Step 1: Centered values
+	centered, err := e.anovaCenter(values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: s_g·(1/√p_g) for every group
+	terms := make([]*HEData, len(groups))
+	for g, mask := range groups {
+		if mask.Size() != values.Size() {
+			return nil, fmt.Errorf("size mismatch in group %d: %d vs %d", g, mask.Size(), values.Size())
+		}
+		prop, err := e.Mean(mask)
+		if err != nil {
+			return nil, fmt.Errorf("proportion of group %d: %w", g, err)
+		}
+		if prop, err = e.selectOneCtxt(prop); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (proportion of group %d): %w", g, err)
+		}
+		invSqrtProp, err := s.InvSqrt(e, prop, 0.5)
+		if err != nil {
+			return nil, fmt.Errorf("invSqrt (proportion of group %d): %w", g, err)
+		}
+
+		masked, err := e.Mult(centered, mask)
+		if err != nil {
+			return nil, fmt.Errorf("mask group %d: %w", g, err)
+		}
+		sum, err := e.Mean(masked)
+		if err != nil {
+			return nil, fmt.Errorf("sum of group %d: %w", g, err)
+		}
+		if sum, err = e.selectOneCtxt(sum); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (sum of group %d): %w", g, err)
+		}
+
+		// The squared terms must keep enough levels for InvSqrt on SSW/n
+		if e.IsBTS {
+			if invSqrtProp, err = e.DoBootstrap(invSqrtProp, 4); err != nil {
+				return nil, fmt.Errorf("bootstrap (1/√p of group %d): %w", g, err)
+			}
+			if sum, err = e.DoBootstrap(sum, 4); err != nil {
+				return nil, fmt.Errorf("bootstrap (sum of group %d): %w", g, err)
+			}
+		}
+		if terms[g], err = e.Mult(sum, invSqrtProp); err != nil {
+			return nil, fmt.Errorf("weighted sum of group %d: %w", g, err)
+		}
+	}
+
+	return e.anovaF(centered, terms, B, s)
+}
+
+// ANOVAPlain is ANOVA with plaintext 0/1 membership masks. The group sizes are then public, so
+// no encrypted inverse is needed for the group terms: S_g/√(n·n_g) is a single masked sum.
+func (e *HEEngine) ANOVAPlain(values *HEData, groups [][]float64, B float64, s InvSqrtStrategy) (*ANOVAResult, error) {
+	if len(groups) < 2 {
+		return nil, fmt.Errorf("ANOVA needs at least two groups, got %d", len(groups))
+	}
+	n := float64(values.Size())
+
+	// Step 1: Centered values
+	centered, err := e.anovaCenter(values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: s_g/√p_g = S_g/√(n·n_g) for every group
+	terms := make([]*HEData, len(groups))
+	for g, mask := range groups {
+		if len(mask) != values.Size() {
+			return nil, fmt.Errorf("size mismatch in group %d: %d vs %d", g, len(mask), values.Size())
+		}
+		count := 0.0
+		for _, m := range mask {
+			count += m
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("group %d is empty", g)
+		}
+
+		weights := make([]float64, len(mask))
+		for i, m := range mask {
+			weights[i] = m / math.Sqrt(n*count)
+		}
+		masked, err := e.MultPlain(centered, weights)
+		if err != nil {
+			return nil, fmt.Errorf("mask group %d: %w", g, err)
+		}
+		if terms[g], err = e.Sum(masked); err != nil {
+			return nil, fmt.Errorf("sum of group %d: %w", g, err)
+		}
+		if terms[g], err = e.selectOneCtxt(terms[g]); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (group %d): %w", g, err)
+		}
+	}
+
+	return e.anovaF(centered, terms, B, s)
+}
+
+// anovaCenter returns values - μ. Mean leaves the unused slots at zero, and so does the difference.
+func (e *HEEngine) anovaCenter(values *HEData) (*HEData, error) {
+	mean, err := e.Mean(values)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	centered, err := e.Sub(values, mean)
+	if err != nil {
+		return nil, fmt.Errorf("center values: %w", err)
+	}
+	return centered, nil
+}
+
+// anovaF computes the F statistic from the centered values and the group terms s_g/√p_g (see
+// ANOVA).
+func (e *HEEngine) anovaF(centered *HEData, terms []*HEData, B float64, s InvSqrtStrategy) (*ANOVAResult, error) {
+	n, k := centered.Size(), len(terms)
+	if n <= k {
+		return nil, fmt.Errorf("ANOVA needs more values than groups, got %d and %d", n, k)
+	}
+
+	// Step 3: SSB/n = Σ s_g²/p_g
+	var between *HEData
+	for g, term := range terms {
+		sq, err := e.Mult(term, term)
+		if err != nil {
+			return nil, fmt.Errorf("square of group %d: %w", g, err)
+		}
+		if between == nil {
+			between = sq
+		} else if between, err = e.Add(between, sq); err != nil {
+			return nil, fmt.Errorf("accumulate group %d: %w", g, err)
+		}
+	}
+
+	// Step 4: SSW/n = Var[x] - SSB/n
+	sq, err := e.Mult(centered, centered)
+	if err != nil {
+		return nil, fmt.Errorf("squared deviations: %w", err)
+	}
+	variance, err := e.Mean(sq)
+	if err != nil {
+		return nil, fmt.Errorf("variance: %w", err)
+	}
+	if variance, err = e.selectOneCtxt(variance); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (variance): %w", err)
+	}
+	within, err := e.Sub(variance, between)
+	if err != nil {
+		return nil, fmt.Errorf("within-group sum of squares: %w", err)
+	}
+
+	// Step 5: F = (n-k)/(k-1) · SSB × (1/√SSW)²
+	invSqrtWithin, err := s.InvSqrt(e, within, B*B)
+	if err != nil {
+		return nil, fmt.Errorf("invSqrt (within-group sum of squares): %w", err)
+	}
+	if e.IsBTS {
+		if invSqrtWithin, err = e.DoBootstrap(invSqrtWithin, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/√SSW): %w", err)
+		}
+		if between, err = e.DoBootstrap(between, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (SSB): %w", err)
+		}
+	}
+	invWithin, err := e.Mult(invSqrtWithin, invSqrtWithin)
+	if err != nil {
+		return nil, fmt.Errorf("1/SSW: %w", err)
+	}
+	if between, err = e.MultConst(between, float64(n-k)/float64(k-1)); err != nil {
+		return nil, fmt.Errorf("scale SSB: %w", err)
+	}
+	f, err := e.Mult(between, invWithin)
+	if err != nil {
+		return nil, fmt.Errorf("F statistic: %w", err)
+	}
+
+	return &ANOVAResult{F: f, DFBetween: k - 1, DFWithin: n - k}, nil
+}
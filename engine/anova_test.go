@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// anovaData returns values whose mean depends on one of three group labels.
+func anovaData(seed int64, n int) ([]float64, []string) {
+	values := normalData(seed, n, 0, 1.5)
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = []string{"a", "b", "c"}[i%3]
+		values[i] += 0.4 * float64(i%3)
+	}
+	return values, labels
+}
+
+func TestANOVA(t *testing.T) {
+	e := testEngine(t)
+	values, labels := anovaData(1, 300)
+	ct := encryptTest(t, e, values)
+	_, groups := utils.OneHot(labels)
+	f, dfBetween, dfWithin := utils.ANOVA(values, labels)
+
+	_, masks, err := e.EncryptOneHot(labels, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := e.ANOVA(ct, masks, 5, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkScalar(t, "F", decryptTest(t, e, res.F, nil), f, 1e-3)
+	if res.DFBetween != dfBetween || res.DFWithin != dfWithin {
+		t.Errorf("DF = (%d, %d), want (%d, %d)", res.DFBetween, res.DFWithin, dfBetween, dfWithin)
+	}
+
+	res, err = e.ANOVAPlain(ct, groups, 5, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkScalar(t, "F (plaintext groups)", decryptTest(t, e, res.F, nil), f, 1e-3)
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// approxMaxLogDegree bounds the Chebyshev degrees 2^k-1 tried by NewApproxFunctionError.
+const approxMaxLogDegree = 10
+
+// approxSamples is the number of intervals of the grid on which the error of an ApproxFunction is
+// measured.
+const approxSamples = 1024
+
+// ApproxFunction is a Chebyshev interpolant of F on [A, B]. The interpolant is computed on
+// [-1, 1] for F composed with the affine map onto [A, B], and EvalApprox applies the inverse map
+// to the input, so that any interval can be used. Error is the largest absolute error of the
+// interpolant, sampled on a grid of the interval. Outside [A, B] the polynomial diverges quickly.
+type ApproxFunction struct {
+	F      func(float64) float64
+	A, B   float64
+	Degree int
+	Error  float64
+	poly   bignum.Polynomial
+}
+
+// NewApproxFunction interpolates f on [a, b] with a polynomial of the given degree. A degree of
+// 2^k-1 uses all the k levels of the evaluation; one more level maps the input onto [-1, 1].
+func NewApproxFunction(f func(float64) float64, a, b float64, degree int) (*ApproxFunction, error) {
+	if !(b > a) {
+		return nil, fmt.Errorf("invalid approximation interval [%g, %g]", a, b)
+	}
+	if degree < 1 {
+		return nil, fmt.Errorf("invalid approximation degree: %d", degree)
+	}
+	g := func(u float64) float64 {
+		return f((b-a)/2*u + (a+b)/2)
+	}
+	fn := &ApproxFunction{F: f, A: a, B: b, Degree: degree, poly: GetChebyshevPoly(1.0, degree, g)}
+	for i := 0; i <= approxSamples; i++ {
+		u := -1 + 2*float64(i)/approxSamples
+		y, _ := fn.poly.Evaluate(u).Real().Float64()
+		fn.Error = max(fn.Error, math.Abs(y-g(u)))
+	}
+	return fn, nil
+}
+
+// NewApproxFunctionError interpolates f on [a, b] with the smallest degree 2^k-1, up to
+// maxDegree, whose sampled absolute error is at most maxError.
+func NewApproxFunctionError(f func(float64) float64, a, b, maxError float64, maxDegree int) (*ApproxFunction, error) {
+	if !(maxError > 0) {
+		return nil, fmt.Errorf("invalid approximation error: %g", maxError)
+	}
+	var best *ApproxFunction
+	for k := 1; k <= approxMaxLogDegree && 1<<k-1 <= maxDegree; k++ {
+		fn, err := NewApproxFunction(f, a, b, 1<<k-1)
+		if err != nil {
+			return nil, err
+		}
+		if fn.Error <= maxError {
+			return fn, nil
+		}
+		best = fn
+	}
+	if best == nil {
+		return nil, fmt.Errorf("invalid maximum degree: %d", maxDegree)
+	}
+	return nil, fmt.Errorf("cannot reach error %g on [%g, %g] up to degree %d (error %g at degree %d)", maxError, a, b, maxDegree, best.Error, best.Degree)
+}
+
+// Depth returns the levels consumed by EvalApprox.
+func (f *ApproxFunction) Depth() int {
+	return bits.Len(uint(f.Degree)) + 1
+}
+
+// EvalApprox approximates f.F(x) for every x in ct, which must lie in [f.A, f.B]. With
+// bootstrapping, the input is refreshed when fewer than f.Depth() levels remain.
+func (e *HEEngine) EvalApprox(ct *HEData, f *ApproxFunction) (*HEData, error) {
+	x := ct
+	var err error
+	if e.IsBTS {
+		if x, err = e.DoBootstrap(x, f.Depth()); err != nil {
+			return nil, fmt.Errorf("bootstrap (approximation input): %w", err)
+		}
+	}
+
+	// Map [A, B] onto [-1, 1]
+	u, err := e.SubConst(x, (f.A+f.B)/2)
+	if err != nil {
+		return nil, fmt.Errorf("center input: %w", err)
+	}
+	if u, err = e.MultConst(u, 2/(f.B-f.A)); err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+
+	polyEval := polynomial.NewEvaluator(e.params, e.Evaluator())
+	return e.evalRealPoly(polyEval, u, polynomial.NewPolynomial(f.poly))
+}
+
+// ApproxExp interpolates e^x on [a, b].
+func ApproxExp(a, b float64, degree int) (*ApproxFunction, error) {
+	return NewApproxFunction(math.Exp, a, b, degree)
+}
+
+// ApproxLog interpolates ln(x) on [a, b], with a > 0.
+func ApproxLog(a, b float64, degree int) (*ApproxFunction, error) {
+	if !(a > 0) {
+		return nil, fmt.Errorf("invalid log interval [%g, %g]", a, b)
+	}
+	return NewApproxFunction(math.Log, a, b, degree)
+}
+
+// ApproxSigmoid interpolates σ(x) = 1/(1 + e^-x) on [-interval, interval].
+func ApproxSigmoid(interval float64, degree int) (*ApproxFunction, error) {
+	sigmoid := func(x float64) float64 {
+		return 1 / (1 + math.Exp(-x))
+	}
+	return NewApproxFunction(sigmoid, -interval, interval, degree)
+}
+
+// ApproxInverse interpolates 1/x on [a, b], which must not contain 0. For a relative precision
+// with Newton refinement, see Inverse.
+func ApproxInverse(a, b float64, degree int) (*ApproxFunction, error) {
+	if a <= 0 && b >= 0 {
+		return nil, fmt.Errorf("invalid inverse interval [%g, %g]", a, b)
+	}
+	inverse := func(x float64) float64 {
+		return 1 / x
+	}
+	return NewApproxFunction(inverse, a, b, degree)
+}
+
+// ApproxSqrt interpolates √x on [a, b], with a ≥ 0. The error is largest close to 0, where √x
+// has no good polynomial approximation; see also Sqrt.
+func ApproxSqrt(a, b float64, degree int) (*ApproxFunction, error) {
+	if a < 0 {
+		return nil, fmt.Errorf("invalid sqrt interval [%g, %g]", a, b)
+	}
+	return NewApproxFunction(math.Sqrt, a, b, degree)
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// checkApprox compares EvalApprox on a grid of [f.A, f.B] with f.F, within the sampled error of
+// the interpolant, and checks the levels it consumes against Depth.
+func checkApprox(t *testing.T, e *HEEngine, name string, f *ApproxFunction, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.poly.Degree(); got != f.Degree {
+		t.Errorf("%s: interpolant of degree %d, want %d", name, got, f.Degree)
+	}
+	x := utils.Linspace(f.A, f.B, 100)
+	want := make([]float64, len(x))
+	for i := range want {
+		want[i] = f.F(x[i])
+	}
+	ct := encryptTest(t, e, x)
+	y, err := e.EvalApprox(ct, f)
+	checkClose(t, name, decryptTest(t, e, y, err), want, f.Error+1e-6)
+	if depth := ct.Level() - y.Level(); depth != f.Depth() {
+		t.Errorf("%s: EvalApprox consumes %d levels, Depth is %d", name, depth, f.Depth())
+	}
+}
+
+func TestApprox(t *testing.T) {
+	e := testEngine(t)
+
+	f, err := ApproxExp(-2, 2, 15)
+	checkApprox(t, e, "exp", f, err)
+	f, err = ApproxLog(1, 10, 31)
+	checkApprox(t, e, "log", f, err)
+	f, err = ApproxSigmoid(8, 31)
+	checkApprox(t, e, "sigmoid", f, err)
+	f, err = ApproxInverse(1, 4, 15)
+	checkApprox(t, e, "inverse", f, err)
+	f, err = ApproxSqrt(1, 100, 31)
+	checkApprox(t, e, "sqrt", f, err)
+}
+
+func TestNewApproxFunctionError(t *testing.T) {
+	f, err := NewApproxFunctionError(func(x float64) float64 { return x * x * x }, -1, 1, 1e-9, 63)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// x³ is exactly a polynomial of degree 3
+	if f.Degree != 3 {
+		t.Errorf("degree %d for x³, want 3", f.Degree)
+	}
+	if _, err := NewApproxFunctionError(math.Abs, -1, 1, 1e-9, 63); err == nil {
+		t.Error("no error for an unreachable precision")
+	}
+}
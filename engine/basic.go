@@ -202,7 +202,10 @@ func (e *HEEngine) MultConst(ct *HEData, con float64) (*HEData, error) {
 
 	// Prepare output slice
 	ctxts := make([]*rlwe.Ciphertext, ctNum)
-	skipRescale := utils.IsPowerOfTwo(con)
+	// A power-of-two constant is an integer and can be applied without rescaling, but only as a
+	// scalar: a vector is always encoded at the scale of the current modulus. The scalar cannot
+	// mask the unused slots, so this shortcut requires every ciphertext to be full.
+	skipRescale := utils.IsPowerOfTwo(con) && ct.Size()%e.params.MaxSlots() == 0
 
 	// Perform element-wise addition
 	SIZE := ct.Size()
@@ -220,7 +223,13 @@ func (e *HEEngine) MultConst(ct *HEData, con float64) (*HEData, error) {
 			}
 		}
 
-		ctNew, err := e.Evaluator().MulNew(ct.Ciphertexts()[i], consts)
+		var ctNew *rlwe.Ciphertext
+		var err error
+		if skipRescale {
+			ctNew, err = e.Evaluator().MulNew(ct.Ciphertexts()[i], con)
+		} else {
+			ctNew, err = e.Evaluator().MulNew(ct.Ciphertexts()[i], consts)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("MulNew failed at index %d: %w", i, err)
 		}
@@ -268,6 +277,15 @@ func (e *HEEngine) Sum(ct *HEData) (result *HEData, err error) {
 	return result, nil
 }
 
+// InnerProduct returns Σᵢ ct1ᵢ·ct2ᵢ, replicated in every slot.
+func (e *HEEngine) InnerProduct(ct1, ct2 *HEData) (result *HEData, err error) {
+	prod, err := e.Mult(ct1, ct2)
+	if err != nil {
+		return nil, fmt.Errorf("element-wise product failed: %w", err)
+	}
+	return e.Sum(prod)
+}
+
 func (e *HEEngine) Mean(ct *HEData) (result *HEData, err error) {
 	sumCtxt, err := e.Sum(ct)
 	if err != nil {
@@ -303,3 +321,99 @@ func (e *HEEngine) Variance(ct *HEData) (result *HEData, err error) {
 
 	return e.Sub(meanXSquared, meanXSquared2)
 }
+
+// MultPlain performs element-wise multiplication of ct by a plaintext vector, followed by rescaling.
+// The vector is laid out like the input of Encrypt: entry j multiplies slot j%Slots of ciphertext j/Slots.
+// Slots without a corresponding entry are multiplied by zero, so MultPlain can also be used as a mask.
+func (e *HEEngine) MultPlain(ct *HEData, values []float64) (*HEData, error) {
+	// Determine output metadata: size, level, scale
+	size := ct.Size()
+	level := ct.Level()
+	scale := ct.Scale()
+	ctNum := len(ct.Ciphertexts())
+	slots := e.params.MaxSlots()
+
+	// Prepare output slice
+	ctxts := make([]*rlwe.Ciphertext, ctNum)
+
+	// Perform element-wise multiplication
+	for i := 0; i < ctNum; i++ {
+		chunk := make([]float64, slots)
+		if start := i * slots; start < len(values) {
+			copy(chunk, values[start:min(start+slots, len(values))])
+		}
+
+		ctNew, err := e.Evaluator().MulNew(ct.Ciphertexts()[i], chunk)
+		if err != nil {
+			return nil, fmt.Errorf("MulNew failed at index %d: %w", i, err)
+		}
+		// Rescale to default scale
+		if err = e.Evaluator().Rescale(ctNew, ctNew); err != nil {
+			return nil, fmt.Errorf("Rescale failed at index %d: %w", i, err)
+		}
+		ctxts[i] = ctNew
+	}
+
+	return NewHEData(ctxts, size, level-1, scale), nil
+}
+
+// Rotate cyclically rotates the slots of every ciphertext of ct by k positions to the left.
+// A negative k rotates to the right. Arbitrary offsets are decomposed into the power-of-two
+// rotations for which the engine holds Galois keys.
+func (e *HEEngine) Rotate(ct *HEData, k int) (*HEData, error) {
+	slots := e.params.MaxSlots()
+	k = ((k % slots) + slots) % slots
+
+	ctxts := make([]*rlwe.Ciphertext, len(ct.Ciphertexts()))
+	for i, c := range ct.Ciphertexts() {
+		ctxt := c.CopyNew()
+		for j := 0; k>>j > 0; j++ {
+			if (k>>j)&1 == 0 {
+				continue
+			}
+			tmp, err := e.evaluator.RotateNew(ctxt, 1<<j)
+			if err != nil {
+				return nil, fmt.Errorf("rotation failed at %d: %w", 1<<j, err)
+			}
+			ctxt = tmp
+		}
+		ctxts[i] = ctxt
+	}
+
+	return NewHEData(ctxts, ct.Size(), ct.Level(), ct.Scale()), nil
+}
+
+// sumShifted returns Σ_{j<count} Rotate(ct, j·step) using O(log count) rotations.
+func (e *HEEngine) sumShifted(ct *HEData, step, count int) (*HEData, error) {
+	var result *HEData
+	power, span, offset := ct, 1, 0
+
+	for count > 0 {
+		if count&1 == 1 {
+			shifted, err := e.Rotate(power, offset*step)
+			if err != nil {
+				return nil, err
+			}
+			if result == nil {
+				result = shifted
+			} else if result, err = e.Add(result, shifted); err != nil {
+				return nil, err
+			}
+			offset += span
+		}
+
+		count >>= 1
+		if count > 0 {
+			doubled, err := e.Rotate(power, span*step)
+			if err != nil {
+				return nil, err
+			}
+			if power, err = e.Add(power, doubled); err != nil {
+				return nil, err
+			}
+			span *= 2
+		}
+	}
+
+	return result, nil
+}
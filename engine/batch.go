@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// BatchedInvSqrt returns 1/√v for every replicated scalar v, e.g. the variances of different
+// columns, groups or datasets, each replicated in a single ciphertext, with one inverse square
+// root of s for all of them instead of one per scalar. Scalar j is moved to slot j of a
+// ciphertext that holds the first scalar in every other slot, including the unused ones that
+// Mean leaves at zero, so that every slot stays in the domain of InvSqrt. B bounds the scalars
+// as in InvSqrtStrategy.InvSqrt: 0 < v ≤ 2B.
+func (e *HEEngine) BatchedInvSqrt(values []*HEData, B float64, s InvSqrtStrategy) ([]*HEData, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values")
+	}
+	if len(values) > e.params.MaxSlots() {
+		return nil, fmt.Errorf("too many values for one ciphertext: %d", len(values))
+	}
+
+	// Step 1: Pack the values, v_0 + Σ_j e_j·(v_j - v_0), with v_0 in all slots
+	packed, err := e.MultPlain(values[0], unitVector(0))
+	if err != nil {
+		return nil, fmt.Errorf("select value 0: %w", err)
+	}
+	if packed, err = e.Sum(packed); err != nil {
+		return nil, fmt.Errorf("replicate value 0: %w", err)
+	}
+	for j := 1; j < len(values); j++ {
+		diff, err := e.Sub(values[j], values[0])
+		if err != nil {
+			return nil, fmt.Errorf("pack value %d: %w", j, err)
+		}
+		if diff, err = e.MultPlain(diff, unitVector(j)); err != nil {
+			return nil, fmt.Errorf("pack value %d: %w", j, err)
+		}
+		if packed, err = e.Add(packed, diff); err != nil {
+			return nil, fmt.Errorf("pack value %d: %w", j, err)
+		}
+	}
+	packed = NewHEData(packed.Ciphertexts(), e.params.MaxSlots(), packed.Level(), packed.Scale())
+
+	// Step 2: 1/√v in every slot, bootstrapped as v/(2B) ∈ (0, 1], where the bootstrapping is
+	// the most precise
+	if e.IsBTS {
+		if packed, err = e.MultConst(packed, 1/(2*B)); err != nil {
+			return nil, fmt.Errorf("normalize packed values: %w", err)
+		}
+		if packed, err = e.DoBootstrap(packed, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (packed values): %w", err)
+		}
+		if packed, err = e.MultConst(packed, 2*B); err != nil {
+			return nil, fmt.Errorf("restore packed values: %w", err)
+		}
+	}
+	inv, err := s.InvSqrt(e, packed, B)
+	if err != nil {
+		return nil, fmt.Errorf("invSqrt (packed values): %w", err)
+	}
+	if e.IsBTS {
+		if inv, err = e.DoBootstrap(inv, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (packed 1/√v): %w", err)
+		}
+	}
+
+	// Step 3: Replicate slot j
+	invs := make([]*HEData, len(values))
+	for j := range values {
+		slot, err := e.MultPlain(inv, unitVector(j))
+		if err != nil {
+			return nil, fmt.Errorf("select 1/√v %d: %w", j, err)
+		}
+		if slot, err = e.Sum(slot); err != nil {
+			return nil, fmt.Errorf("replicate 1/√v %d: %w", j, err)
+		}
+		invs[j] = NewHEData(slot.Ciphertexts(), values[j].Size(), slot.Level(), slot.Scale())
+	}
+	return invs, nil
+}
+
+// BatchedInvStd computes 1/σ for the population standard deviation σ of every column with
+// BatchedInvSqrt, each replicated in the valid slots of a single ciphertext. The population
+// variance of every column must be at most 2B², as in InvSqrtStrategy.InvStd.
+func (e *HEEngine) BatchedInvStd(cols []*HEData, B float64, s InvSqrtStrategy) ([]*HEData, error) {
+	variances := make([]*HEData, len(cols))
+	for j, col := range cols {
+		n := float64(col.Size())
+		variance, err := varianceWithCustomDenom(e, col, n, n)
+		if err != nil {
+			return nil, fmt.Errorf("variance of column %d: %w", j, err)
+		}
+		if variances[j], err = e.selectOneCtxt(variance); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (variance of column %d): %w", j, err)
+		}
+	}
+	return e.BatchedInvSqrt(variances, B*B, s)
+}
+
+// CorrMatrix computes the Pearson correlation matrix of the columns, with the inverse standard
+// deviations of all columns from a single BatchedInvStd. Entry (j, k) is replicated in the slots
+// of a single ciphertext; the diagonal is left nil, and entry (k, j) is entry (j, k).
+func (e *HEEngine) CorrMatrix(cols []*HEData, B float64, s InvSqrtStrategy) ([][]*HEData, error) {
+	p := len(cols)
+	if p < 2 {
+		return nil, fmt.Errorf("need at least two columns: %d", p)
+	}
+	for j := range cols {
+		if cols[j].Size() != cols[0].Size() {
+			return nil, fmt.Errorf("size mismatch in column %d: %d vs %d", j, cols[j].Size(), cols[0].Size())
+		}
+	}
+
+	// Step 1: 1/σ of every column with one inverse square root
+	invStd, err := e.BatchedInvStd(cols, B, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Centered columns
+	centered := make([]*HEData, p)
+	for j, col := range cols {
+		mean, err := e.Mean(col)
+		if err != nil {
+			return nil, fmt.Errorf("mean of column %d: %w", j, err)
+		}
+		if centered[j], err = e.Sub(col, mean); err != nil {
+			return nil, fmt.Errorf("center column %d: %w", j, err)
+		}
+	}
+
+	// Step 3: corr[j][k] = E[(xⱼ - μⱼ)(xₖ - μₖ)] × (1/σⱼ)(1/σₖ)
+	corr := make([][]*HEData, p)
+	for j := range corr {
+		corr[j] = make([]*HEData, p)
+	}
+	invN := 1.0 / float64(cols[0].Size())
+	for j := 0; j < p; j++ {
+		for k := j + 1; k < p; k++ {
+			ip, err := e.InnerProduct(centered[j], centered[k])
+			if err != nil {
+				return nil, fmt.Errorf("covariance (%d, %d): %w", j, k, err)
+			}
+			if ip, err = e.selectOneCtxt(ip); err != nil {
+				return nil, fmt.Errorf("selectOneCtxt (covariance (%d, %d)): %w", j, k, err)
+			}
+			cov, err := e.MultConst(ip, invN)
+			if err != nil {
+				return nil, fmt.Errorf("covariance (%d, %d): %w", j, k, err)
+			}
+			invX, invY := invStd[j], invStd[k]
+			if e.IsBTS {
+				if invX, err = e.DoBootstrap(invX, 2); err != nil {
+					return nil, fmt.Errorf("bootstrap (1/σ of column %d): %w", j, err)
+				}
+				if invY, err = e.DoBootstrap(invY, 2); err != nil {
+					return nil, fmt.Errorf("bootstrap (1/σ of column %d): %w", k, err)
+				}
+				if cov, err = e.DoBootstrap(cov, 1); err != nil {
+					return nil, fmt.Errorf("bootstrap (covariance (%d, %d)): %w", j, k, err)
+				}
+			}
+			invXY, err := e.Mult(invX, invY)
+			if err != nil {
+				return nil, fmt.Errorf("1/σ product (%d, %d): %w", j, k, err)
+			}
+			if corr[j][k], err = e.Mult(cov, invXY); err != nil {
+				return nil, fmt.Errorf("correlation (%d, %d): %w", j, k, err)
+			}
+			corr[k][j] = corr[j][k]
+		}
+	}
+	return corr, nil
+}
+
+// unitVector returns the plaintext mask of slot j for MultPlain.
+func unitVector(j int) []float64 {
+	v := make([]float64, j+1)
+	v[j] = 1
+	return v
+}
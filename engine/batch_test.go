@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// batchColumns returns p correlated columns with standard deviations 1, 2, ..., p.
+func batchColumns(p, n int) [][]float64 {
+	common := normalData(100, n, 0, 1)
+	cols := make([][]float64, p)
+	for j := range cols {
+		cols[j] = normalData(int64(j+1), n, float64(j), float64(j+1))
+		for i := range cols[j] {
+			cols[j][i] += float64(j+1) * common[i]
+		}
+	}
+	return cols
+}
+
+func TestBatchedInvSqrt(t *testing.T) {
+	e := testEngine(t)
+	x := []float64{0.5, 3, 12, 20}
+	values := make([]*HEData, len(x))
+	for j, v := range x {
+		values[j] = encryptTest(t, e, []float64{v, v, v, v, v})
+	}
+	inv, err := e.BatchedInvSqrt(values, 10, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, want := range invSqrtWant(x) {
+		checkScalar(t, fmt.Sprintf("1/√v[%d]", j), decryptTest(t, e, inv[j], nil), want, 1e-4)
+	}
+}
+
+func TestCorrMatrix(t *testing.T) {
+	e := testEngine(t)
+	data := batchColumns(4, 200)
+	cols := make([]*HEData, len(data))
+	for j := range data {
+		cols[j] = encryptTest(t, e, data[j])
+	}
+
+	invStd, err := e.BatchedInvStd(cols, 10, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range data {
+		checkScalar(t, fmt.Sprintf("1/σ[%d]", j), decryptTest(t, e, invStd[j], nil), 1/utils.StdDev(data[j]), 1e-4)
+	}
+
+	corr, err := e.CorrMatrix(cols, 10, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range data {
+		for k := j + 1; k < len(data); k++ {
+			_, want, _ := utils.Correlation(data[j], data[k])
+			checkScalar(t, fmt.Sprintf("r[%d][%d]", j, k), decryptTest(t, e, corr[j][k], nil), want, 1e-4)
+			if corr[k][j] != corr[j][k] {
+				t.Errorf("entry (%d, %d) differs from (%d, %d)", k, j, j, k)
+			}
+		}
+	}
+}
+
+// invStdErrors returns the maximum relative errors of 1/σ over the columns with BatchedInvStd and
+// with the InvStd of s on every column.
+func invStdErrors(t *testing.T, e *HEEngine, s InvSqrtStrategy, data [][]float64, B float64) (float64, float64) {
+	t.Helper()
+	cols := make([]*HEData, len(data))
+	for j := range data {
+		cols[j] = encryptTest(t, e, data[j])
+	}
+	batched, err := e.BatchedInvStd(cols, B, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var batchedErr, singleErr float64
+	for j := range data {
+		want := 1 / utils.StdDev(data[j])
+		got := decryptTest(t, e, batched[j], nil)[0]
+		batchedErr = math.Max(batchedErr, math.Abs(got-want)/want)
+
+		single, err := s.InvStd(e, cols[j], B)
+		got = decryptTest(t, e, single, err)[0]
+		singleErr = math.Max(singleErr, math.Abs(got-want)/want)
+	}
+	return batchedErr, singleErr
+}
+
+func TestBatchedInvStdAccuracy(t *testing.T) {
+	e := testEngine(t)
+	data := batchColumns(2, 200)
+
+	// PPStat is as accurate in the batch as on every column
+	batched, single := invStdErrors(t, e, PPStat{}, data, 10)
+	if batched > 1e-6 || single > 1e-6 {
+		t.Errorf("PPStat: batched %.3g, per column %.3g, want both below 1e-6", batched, single)
+	}
+
+	// Without bootstrapping, the ten Newton steps of HEStat amplify the scale drift of the packing
+	// level: the batch is about five times less accurate than the columns
+	batched, single = invStdErrors(t, e, HEStat{Iter: 10}, data, 10)
+	if single > 2e-5 || batched > 1e-4 {
+		t.Errorf("HEStat: batched %.3g, per column %.3g, want below 1e-4 and 2e-5", batched, single)
+	}
+}
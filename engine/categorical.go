@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// chiSquareMinExpected is the usual validity condition of the chi-square test: every expected
+// count must be at least 5. It bounds the marginal proportions from below by 5/n, which gives
+// the interval of their encrypted inverse.
+const chiSquareMinExpected = 5.0
+
+// chiSquarePrecision is the relative error targeted by the inverses of the marginal proportions.
+const chiSquarePrecision = 1e-6
+
+// ChiSquareResult holds an encrypted chi-square statistic, its public degrees of freedom and,
+// when requested, Cramér's V. The encrypted values are in the first slot.
+type ChiSquareResult struct {
+	Statistic *HEData
+	DF        int
+	CramersV  *HEData
+}
+
+// EncryptOneHot encrypts a categorical column as one 0/1 indicator ciphertext per level (see
+// utils.OneHot), returning the levels in the order of the ciphertexts.
+func (e *HEEngine) EncryptOneHot(values []string, level int) ([]string, []*HEData, error) {
+	levels, columns := utils.OneHot(values)
+	cts := make([]*HEData, len(columns))
+	for k, col := range columns {
+		var err error
+		if cts[k], err = e.Encrypt(col, level); err != nil {
+			return nil, nil, fmt.Errorf("encrypt level %q: %w", levels[k], err)
+		}
+	}
+	return levels, cts, nil
+}
+
+// ContingencyTable returns the encrypted cell proportions O_ij/n of two one-hot encoded columns.
+// Each cell is the masked inner product of the indicators a[i] and b[j], divided by n.
+func (e *HEEngine) ContingencyTable(a, b []*HEData) ([][]*HEData, error) {
+	table := make([][]*HEData, len(a))
+	for i := range a {
+		table[i] = make([]*HEData, len(b))
+		for j := range b {
+			if a[i].Size() != b[j].Size() {
+				return nil, fmt.Errorf("size mismatch: %d vs %d", a[i].Size(), b[j].Size())
+			}
+			both, err := e.Mult(a[i], b[j])
+			if err != nil {
+				return nil, fmt.Errorf("indicator product (%d, %d): %w", i, j, err)
+			}
+			if table[i][j], err = e.Mean(both); err != nil {
+				return nil, fmt.Errorf("cell (%d, %d): %w", i, j, err)
+			}
+			if table[i][j], err = e.selectOneCtxt(table[i][j]); err != nil {
+				return nil, fmt.Errorf("selectOneCtxt (%d, %d): %w", i, j, err)
+			}
+		}
+	}
+	return table, nil
+}
+
+// ChiSquare computes Pearson's chi-square test of independence between two one-hot encoded
+// columns (see EncryptOneHot), χ² = n·Σ (o_ij - r_i·c_j)²/(r_i·c_j) on the cell proportions o_ij
+// and the marginal proportions r_i and c_j.
+//
+// Only the r + c marginals are inverted. The expected-count condition n·r_i·c_j ≥ 5 implies
+// r_i, c_j ≥ 5/n, so Inverse is applied to n·r_i/5 ∈ [1, n/5], whose inverse stays in [5/n, 1]
+// and can be bootstrapped accurately. Each cell then contributes the product of the bounded
+// factors √n·(o_ij - r_i·c_j)/r_i and √n·(o_ij - r_i·c_j)/c_j. With cramersV, Cramér's
+// V = √(χ²/(n·(min(r, c)-1))) is added through Sqrt with the strategy s, whose relative error
+// grows for V close to 0.
+func (e *HEEngine) ChiSquare(a, b []*HEData, cramersV bool, s InvSqrtStrategy) (*ChiSquareResult, error) {
+	if len(a) < 2 || len(b) < 2 {
+		return nil, fmt.Errorf("chi-square needs at least two levels per column, got %d and %d", len(a), len(b))
+	}
+	n := float64(a[0].Size())
+	hi := n / chiSquareMinExpected
+
+	// Step 1: Cell proportions
+	table, err := e.ContingencyTable(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("contingency table: %w", err)
+	}
+
+	// Step 2: Marginal proportions p and the scaled inverses 5/(n·p)
+	marginals := func(cols []*HEData) ([]*HEData, []*HEData, error) {
+		props := make([]*HEData, len(cols))
+		invs := make([]*HEData, len(cols))
+		for k, col := range cols {
+			mean, err := e.Mean(col)
+			if err != nil {
+				return nil, nil, fmt.Errorf("marginal %d: %w", k, err)
+			}
+			if props[k], err = e.selectOneCtxt(mean); err != nil {
+				return nil, nil, fmt.Errorf("selectOneCtxt (marginal %d): %w", k, err)
+			}
+			scaled := props[k]
+			if e.IsBTS {
+				if scaled, err = e.DoBootstrap(scaled, e.params.MaxLevel()); err != nil {
+					return nil, nil, fmt.Errorf("bootstrap (marginal %d): %w", k, err)
+				}
+			}
+			if scaled, err = e.MultConst(scaled, hi); err != nil {
+				return nil, nil, fmt.Errorf("scale marginal %d: %w", k, err)
+			}
+			if invs[k], err = e.Inverse(scaled, 1, hi, chiSquarePrecision); err != nil {
+				return nil, nil, fmt.Errorf("inverse of marginal %d: %w", k, err)
+			}
+			if e.IsBTS {
+				if invs[k], err = e.DoBootstrap(invs[k], 2); err != nil {
+					return nil, nil, fmt.Errorf("bootstrap (inverse of marginal %d): %w", k, err)
+				}
+			}
+		}
+		return props, invs, nil
+	}
+	rows, invRows, err := marginals(a)
+	if err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+	cols, invCols, err := marginals(b)
+	if err != nil {
+		return nil, fmt.Errorf("columns: %w", err)
+	}
+
+	// Step 3: Σ over the cells of [s·d_ij·(n/5)·(5/(n·r_i))] × [s·d_ij·(n/5)·(5/(n·c_j))], with
+	// d_ij = o_ij - r_i·c_j and s = √n for χ², or s = 1/√(k-1) for V²
+	k := float64(min(len(a), len(b)))
+	factors := []float64{math.Sqrt(n) * hi}
+	if cramersV {
+		factors = append(factors, hi/math.Sqrt(k-1))
+	}
+	sums := make([]*HEData, len(factors))
+	for i := range table {
+		for j := range table[i] {
+			expected, err := e.Mult(rows[i], cols[j])
+			if err != nil {
+				return nil, fmt.Errorf("expected (%d, %d): %w", i, j, err)
+			}
+			dev, err := e.Sub(table[i][j], expected)
+			if err != nil {
+				return nil, fmt.Errorf("deviation (%d, %d): %w", i, j, err)
+			}
+			if e.IsBTS {
+				if dev, err = e.DoBootstrap(dev, 3); err != nil {
+					return nil, fmt.Errorf("bootstrap (deviation (%d, %d)): %w", i, j, err)
+				}
+			}
+
+			for f, factor := range factors {
+				scaled, err := e.MultConst(dev, factor)
+				if err != nil {
+					return nil, fmt.Errorf("scale deviation (%d, %d): %w", i, j, err)
+				}
+				byRow, err := e.Mult(scaled, invRows[i])
+				if err != nil {
+					return nil, fmt.Errorf("row weight (%d, %d): %w", i, j, err)
+				}
+				byCol, err := e.Mult(scaled, invCols[j])
+				if err != nil {
+					return nil, fmt.Errorf("column weight (%d, %d): %w", i, j, err)
+				}
+				term, err := e.Mult(byRow, byCol)
+				if err != nil {
+					return nil, fmt.Errorf("cell term (%d, %d): %w", i, j, err)
+				}
+				if sums[f] == nil {
+					sums[f] = term
+				} else if sums[f], err = e.Add(sums[f], term); err != nil {
+					return nil, fmt.Errorf("accumulate (%d, %d): %w", i, j, err)
+				}
+			}
+		}
+	}
+
+	result := &ChiSquareResult{Statistic: sums[0], DF: (len(a) - 1) * (len(b) - 1)}
+	if !cramersV {
+		return result, nil
+	}
+
+	// Step 4: V = √(V²), with V² ∈ [0, 1]
+	v2 := sums[1]
+	if e.IsBTS {
+		if v2, err = e.DoBootstrap(v2, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (V²): %w", err)
+		}
+	}
+	if result.CramersV, err = e.Sqrt(v2, 1, s); err != nil {
+		return nil, fmt.Errorf("Cramér's V: %w", err)
+	}
+	return result, nil
+}
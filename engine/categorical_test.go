@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// categoricalData returns two dependent categorical columns with three and two levels.
+func categoricalData(seed int64, n int) ([]string, []string) {
+	r := rand.New(rand.NewSource(seed))
+	a, b := make([]string, n), make([]string, n)
+	for i := range a {
+		k := r.Intn(3)
+		a[i] = []string{"x", "y", "z"}[k]
+		if r.Float64() < 0.3+0.2*float64(k) {
+			b[i] = "p"
+		} else {
+			b[i] = "q"
+		}
+	}
+	return a, b
+}
+
+// encryptOneHotTest one-hot encodes a and b at the top level of e.
+func encryptOneHotTest(t *testing.T, e *HEEngine, a, b []string) ([]*HEData, []*HEData) {
+	t.Helper()
+	_, ctA, err := e.EncryptOneHot(a, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ctB, err := e.EncryptOneHot(b, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctA, ctB
+}
+
+func TestChiSquare(t *testing.T) {
+	e := testEngine(t)
+	a, b := categoricalData(1, 300)
+	ctA, ctB := encryptOneHotTest(t, e, a, b)
+
+	res, err := e.ChiSquare(ctA, ctB, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chi2, df, _ := utils.ChiSquare(a, b)
+	checkScalar(t, "χ²", decryptTest(t, e, res.Statistic, nil), chi2, 1e-4)
+	if res.DF != df {
+		t.Errorf("DF = %d, want %d", res.DF, df)
+	}
+}
+
+func TestChiSquareCramersV(t *testing.T) {
+	e := testBTSEngine(t)
+	a, b := categoricalData(2, 300)
+	ctA, ctB := encryptOneHotTest(t, e, a, b)
+
+	res, err := e.ChiSquare(ctA, ctB, true, HEDAP{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chi2, _, v := utils.ChiSquare(a, b)
+	checkScalar(t, "χ²", decryptTest(t, e, res.Statistic, nil), chi2, 1e-3)
+	checkScalar(t, "Cramér's V", decryptTest(t, e, res.CramersV, nil), v, 1e-3)
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// signCoeffs are the monomial coefficients of f(x) = (35x - 35x³ + 21x⁵ - 5x⁷)/16.
+// Iterating f pushes every x in [-1, 1] towards sign(x): |x| ≥ 2^-k reaches 1 - 2^-20
+// after roughly k+1 compositions (6 for 2^-4, 9 for 2^-8, 13 for 2^-12).
+var signCoeffs = []float64{0, 35.0 / 16, 0, -35.0 / 16, 0, 21.0 / 16, 0, -5.0 / 16}
+
+// signDepth is the multiplicative depth of a single composition of f.
+const signDepth = 3
+
+// Sign approximates sign(x) for inputs in [-1, 1] by composing f iter times.
+// Each composition consumes signDepth levels; with bootstrapping the input is refreshed
+// whenever fewer levels remain.
+func (e *HEEngine) Sign(ct *HEData, iter int) (*HEData, error) {
+	return e.compositeSign(ct, iter, false)
+}
+
+// Step approximates the step function (1 for x > 0, 0 for x < 0, 0.5 for x = 0) for inputs in
+// [-1, 1]. The affine map (s+1)/2 is folded into the last composition, so Step costs the same
+// depth as Sign.
+func (e *HEEngine) Step(ct *HEData, iter int) (*HEData, error) {
+	return e.compositeSign(ct, iter, true)
+}
+
+func (e *HEEngine) compositeSign(ct *HEData, iter int, step bool) (*HEData, error) {
+	if iter < 1 {
+		return nil, fmt.Errorf("invalid sign iteration count: %d", iter)
+	}
+
+	stepCoeffs := make([]float64, len(signCoeffs))
+	for i, c := range signCoeffs {
+		stepCoeffs[i] = c / 2
+	}
+	stepCoeffs[0] += 0.5
+
+	polyEval := polynomial.NewEvaluator(e.params, e.Evaluator())
+	signPoly := polynomial.NewPolynomial(bignum.NewPolynomial(bignum.Monomial, signCoeffs, nil))
+	stepPoly := polynomial.NewPolynomial(bignum.NewPolynomial(bignum.Monomial, stepCoeffs, nil))
+
+	y := ct.CopyData()
+	for i := range iter {
+		var err error
+		if e.IsBTS {
+			if y, err = e.DoBootstrap(y, signDepth); err != nil {
+				return nil, fmt.Errorf("bootstrap (sign iteration %d): %w", i, err)
+			}
+		}
+
+		poly := signPoly
+		if step && i == iter-1 {
+			poly = stepPoly
+		}
+
+		if y, err = e.evalRealPoly(polyEval, y, poly); err != nil {
+			return nil, fmt.Errorf("sign iteration %d: %w", i, err)
+		}
+	}
+	return y, nil
+}
+
+// evalRealPoly evaluates poly on every ciphertext of ct and discards the imaginary part of the
+// result, which would otherwise accumulate across compositions.
+func (e *HEEngine) evalRealPoly(polyEval *polynomial.Evaluator, ct *HEData, poly polynomial.Polynomial) (*HEData, error) {
+	ctxts := ct.Ciphertexts()
+	out := make([]*rlwe.Ciphertext, len(ctxts))
+	targetScale := e.params.DefaultScale().Div(rlwe.NewScale(2))
+	for i := 0; i < len(ctxts); i++ {
+		p, err := polyEval.Evaluate(ctxts[i], poly, targetScale)
+		if err != nil {
+			return nil, fmt.Errorf("polynomial evaluation failed at index %d: %w", i, err)
+		}
+		p.Scale = p.Scale.Mul(rlwe.NewScale(2))
+		conj, err := e.evaluator.ConjugateNew(p)
+		if err != nil {
+			return nil, fmt.Errorf("conjugation failed at index %d: %w", i, err)
+		}
+		if err = e.evaluator.Add(p, conj, p); err != nil {
+			return nil, fmt.Errorf("addition failed at index %d: %w", i, err)
+		}
+		p.Scale = ctxts[i].Scale
+		out[i] = p
+	}
+	return NewHEData(out, ct.Size(), out[0].Level(), ct.Scale()), nil
+}
@@ -23,6 +23,7 @@ type HEEngine struct {
 	BTS       *bootstrapping.Evaluator
 	Slots     int
 	IsBTS     bool
+	Polys     *PolyCache
 }
 
 func (e *HEEngine) Evaluator() *ckks.Evaluator { return e.evaluator }
@@ -78,6 +79,15 @@ func GetBSParam(LogN int, LEVEL int, SCALE int) (ckks.Parameters, bootstrapping.
 }
 
 func NewHEEngine(isBTS bool, params ckks.Parameters, btpParams bootstrapping.Parameters) *HEEngine {
+	return NewHEEngineWithPolys(isBTS, params, btpParams, NewPolyCache())
+}
+
+// NewHEEngineWithPolys constructs an engine whose Chebyshev interpolants come from polys, e.g. a
+// cache loaded by LoadPolyCache, and are added to it. A nil cache starts empty.
+func NewHEEngineWithPolys(isBTS bool, params ckks.Parameters, btpParams bootstrapping.Parameters, polys *PolyCache) *HEEngine {
+	if polys == nil {
+		polys = NewPolyCache()
+	}
 	kgen := rlwe.NewKeyGenerator(params)
 	sk, pk := kgen.GenKeyPairNew()
 	rlk := kgen.GenRelinearizationKeyNew(sk)
@@ -119,6 +129,7 @@ func NewHEEngine(isBTS bool, params ckks.Parameters, btpParams bootstrapping.Par
 		BTS:       bts,
 		Slots:     params.MaxSlots(),
 		IsBTS:     isBTS,
+		Polys:     polys,
 	}
 }
 
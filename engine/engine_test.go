@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"runtime/debug"
+	"sync"
+	"testing"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/bootstrapping"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// testLevels is the depth of the engine returned by testEngine, enough for the deepest statistic
+// to run without bootstrapping.
+const testLevels = 120
+
+// testDeepLevels is the depth of the engine returned by testDeepEngine, enough for the sign
+// compositions of the order statistics.
+const testDeepLevels = 320
+
+var (
+	testEngineOnce sync.Once
+	testEngineVal  *HEEngine
+	testDeepOnce   sync.Once
+	testDeepVal    *HEEngine
+	testBTSOnce    sync.Once
+	testBTSVal     *HEEngine
+)
+
+// testMemoryLimit bounds the heap of the tests, which hold about 3 GiB of evaluation keys across
+// the shared engines: with the default GC target the heap could grow to twice that.
+const testMemoryLimit = 4 << 30
+
+// TestMain points HEDAP at the optimizer profile of the repository, relative to the package
+// directory the tests run in.
+func TestMain(m *testing.M) {
+	OptimizerProfile = "../optimizer/result/lattigo_optimizer.json"
+	debug.SetMemoryLimit(testMemoryLimit)
+	os.Exit(m.Run())
+}
+
+// testEngine returns a shared engine without bootstrapping, LogN = 10 and testLevels levels.
+func testEngine(t *testing.T) *HEEngine {
+	t.Helper()
+	testEngineOnce.Do(func() {
+		testEngineVal = newTestEngine(10, testLevels)
+	})
+	return testEngineVal
+}
+
+// testDeepEngine returns a shared engine without bootstrapping, LogN = 6 and testDeepLevels
+// levels. Its 32 slots keep the rounds of Max, Min and Quantile few and cheap.
+func testDeepEngine(t *testing.T) *HEEngine {
+	t.Helper()
+	testDeepOnce.Do(func() {
+		testDeepVal = newTestEngine(6, testDeepLevels)
+	})
+	return testDeepVal
+}
+
+// newTestEngine returns an engine without bootstrapping with the given ring degree and depth.
+// Without bootstrapping the scales of the operands of Add and Sub drift apart by the distance of
+// the primes to the scale, which Newton iterations amplify threefold per step; 50-bit primes keep
+// that drift far below the tolerances of the tests.
+func newTestEngine(logN, levels int) *HEEngine {
+	logQ := make([]int, levels+1)
+	logQ[0] = 60
+	for i := 1; i <= levels; i++ {
+		logQ[i] = 50
+	}
+	params, err := ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+		LogN:            logN,
+		LogQ:            logQ,
+		LogP:            []int{61, 61, 61, 61, 61, 61, 61, 61},
+		LogDefaultScale: 50,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return NewHEEngine(false, params, bootstrapping.Parameters{})
+}
+
+// testBTSEngine returns a shared bootstrapping engine with the parameters of the experiments. It
+// is skipped in short mode.
+func testBTSEngine(t *testing.T) *HEEngine {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("bootstrapping engine skipped in short mode")
+	}
+	testBTSOnce.Do(func() {
+		params, btpParams := GetBSParam(13, 11, 40)
+		testBTSVal = NewHEEngine(true, params, btpParams)
+	})
+	return testBTSVal
+}
+
+// encryptTest encrypts values at the top level of e.
+func encryptTest(t *testing.T, e *HEEngine, values []float64) *HEData {
+	t.Helper()
+	ct, err := e.Encrypt(values, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ct
+}
+
+// decryptTest decrypts ct, failing the test on err.
+func decryptTest(t *testing.T, e *HEEngine, ct *HEData, err error) []float64 {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := e.Decrypt(ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+// checkClose reports the entries of got whose error relative to max(|want|, 1) exceeds tol.
+func checkClose(t *testing.T, name string, got, want []float64, tol float64) {
+	t.Helper()
+	if len(got) < len(want) {
+		t.Fatalf("%s: got %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if err := math.Abs(got[i]-want[i]) / max(math.Abs(want[i]), 1); !(err <= tol) {
+			t.Errorf("%s[%d] = %g, want %g (error %.2e > %.0e)", name, i, got[i], want[i], err, tol)
+			return
+		}
+	}
+}
+
+// checkScalar is checkClose for a statistic replicated in the first slot.
+func checkScalar(t *testing.T, name string, got []float64, want, tol float64) {
+	t.Helper()
+	checkClose(t, name, got[:1], []float64{want}, tol)
+}
+
+// normalData returns n normal samples of the given mean and standard deviation.
+func normalData(seed int64, n int, mean, std float64) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = mean + std*r.NormFloat64()
+	}
+	return data
+}
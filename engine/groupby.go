@@ -0,0 +1,344 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// groupPrecision is the relative error targeted by the inverses of the encrypted group counts.
+const groupPrecision = 1e-6
+
+// GroupStats holds the encrypted statistics of one group, each replicated in every slot of a
+// single ciphertext. Variance, Skewness and Kurtosis are the population estimators of Variance,
+// Skewness and Kurtosis. Correlation is the Pearson correlation with the second column, or nil
+// without one.
+type GroupStats struct {
+	Count       *HEData
+	Mean        *HEData
+	Variance    *HEData
+	Skewness    *HEData
+	Kurtosis    *HEData
+	Correlation *HEData
+}
+
+// groupKey holds the 0/1 membership masks of a categorical key, either encrypted (see
+// EncryptOneHot) with the encrypted inverses 1/n_g of the group counts, or in plaintext with the
+// public counts.
+type groupKey struct {
+	masks     []*HEData
+	invCounts []*HEData
+	plain     [][]float64
+	counts    []float64
+}
+
+func (k *groupKey) len() int {
+	if k.plain != nil {
+		return len(k.plain)
+	}
+	return len(k.masks)
+}
+
+// GroupBy computes the statistics of values within every group of a categorical key given by
+// encrypted 0/1 membership masks (see EncryptOneHot), which must partition the values. other
+// is an optional second column for the per-group correlation, and may be nil.
+//
+// The columns are centered on their global means, then on every group mean, so that the group
+// moments are means of small deviations. The group counts n_g ∈ [1, n] are inverted with
+// Inverse, as the marginals of ChiSquare. The group variances of all groups and columns are
+// packed into the slots of one ciphertext, so that a single inverse square root gives every
+// 1/σ. B bounds the data as in InvSqrtStrategy.InvStd: every group variance must be at most
+// 2B².
+func (e *HEEngine) GroupBy(values, other *HEData, groups []*HEData, B float64, s InvSqrtStrategy) ([]*GroupStats, error) {
+	if err := checkGroupColumns(values, other, len(groups)); err != nil {
+		return nil, err
+	}
+	n := float64(values.Size())
+
+	// Step 1: Encrypted inverses of the group counts
+	key := &groupKey{masks: groups, invCounts: make([]*HEData, len(groups))}
+	for g, mask := range groups {
+		if mask.Size() != values.Size() {
+			return nil, fmt.Errorf("size mismatch in group %d: %d vs %d", g, mask.Size(), values.Size())
+		}
+		prop, err := e.Mean(mask)
+		if err != nil {
+			return nil, fmt.Errorf("proportion of group %d: %w", g, err)
+		}
+		if prop, err = e.selectOneCtxt(prop); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (proportion of group %d): %w", g, err)
+		}
+		if e.IsBTS {
+			if prop, err = e.DoBootstrap(prop, e.params.MaxLevel()); err != nil {
+				return nil, fmt.Errorf("bootstrap (proportion of group %d): %w", g, err)
+			}
+		}
+		count, err := e.MultConst(prop, n)
+		if err != nil {
+			return nil, fmt.Errorf("count of group %d: %w", g, err)
+		}
+		if key.invCounts[g], err = e.Inverse(count, 1, n, groupPrecision); err != nil {
+			return nil, fmt.Errorf("inverse count of group %d: %w", g, err)
+		}
+		if e.IsBTS {
+			if key.invCounts[g], err = e.DoBootstrap(key.invCounts[g], 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (inverse count of group %d): %w", g, err)
+			}
+		}
+	}
+
+	stats, err := e.groupStats(values, other, key, B, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 6: Encrypted counts
+	for g, mask := range groups {
+		count, err := e.Sum(mask)
+		if err != nil {
+			return nil, fmt.Errorf("count of group %d: %w", g, err)
+		}
+		if stats[g].Count, err = e.selectOneCtxt(count); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (count of group %d): %w", g, err)
+		}
+	}
+	return stats, nil
+}
+
+// GroupByPlain is GroupBy with plaintext 0/1 membership masks. The group counts are then
+// public, so the group means need no encrypted inverse and the counts are encrypted as is.
+func (e *HEEngine) GroupByPlain(values, other *HEData, groups [][]float64, B float64, s InvSqrtStrategy) ([]*GroupStats, error) {
+	if err := checkGroupColumns(values, other, len(groups)); err != nil {
+		return nil, err
+	}
+
+	// Step 1: Public group counts
+	key := &groupKey{plain: groups, counts: make([]float64, len(groups))}
+	for g, mask := range groups {
+		if len(mask) != values.Size() {
+			return nil, fmt.Errorf("size mismatch in group %d: %d vs %d", g, len(mask), values.Size())
+		}
+		for _, m := range mask {
+			key.counts[g] += m
+		}
+		if key.counts[g] == 0 {
+			return nil, fmt.Errorf("group %d is empty", g)
+		}
+	}
+
+	stats, err := e.groupStats(values, other, key, B, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 6: Encrypted counts
+	for g, count := range key.counts {
+		replicated := make([]float64, e.params.MaxSlots())
+		for i := range replicated {
+			replicated[i] = count
+		}
+		if stats[g].Count, err = e.Encrypt(replicated, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("encrypt count of group %d: %w", g, err)
+		}
+	}
+	return stats, nil
+}
+
+// checkGroupColumns validates the columns and the number of groups of GroupBy.
+func checkGroupColumns(values, other *HEData, groups int) error {
+	if groups < 1 {
+		return fmt.Errorf("no groups")
+	}
+	if other != nil && other.Size() != values.Size() {
+		return fmt.Errorf("size mismatch between the columns: %d vs %d", values.Size(), other.Size())
+	}
+	return nil
+}
+
+// groupMoments holds the moments of one column within one group: the mean of the globally
+// centered column, the deviations from it (zero outside the group) and the central moments.
+type groupMoments struct {
+	mean       *HEData
+	deviations *HEData
+	moments    map[int]*HEData
+}
+
+// groupStats computes the statistics of GroupBy from the group key.
+func (e *HEEngine) groupStats(values, other *HEData, key *groupKey, B float64, s InvSqrtStrategy) ([]*GroupStats, error) {
+	columns := []*HEData{values}
+	if other != nil {
+		columns = append(columns, other)
+	}
+	groups := key.len()
+
+	// Step 2: Group means and central moments of every column
+	means := make([]*HEData, len(columns))
+	moments := make([][]*groupMoments, len(columns))
+	for c, col := range columns {
+		mean, err := e.Mean(col)
+		if err != nil {
+			return nil, fmt.Errorf("mean of column %d: %w", c, err)
+		}
+		centered, err := e.Sub(col, mean)
+		if err != nil {
+			return nil, fmt.Errorf("center column %d: %w", c, err)
+		}
+		if means[c], err = e.selectOneCtxt(mean); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (mean of column %d): %w", c, err)
+		}
+		moments[c] = make([]*groupMoments, groups)
+		for g := range groups {
+			if moments[c][g], err = e.groupCentralMoments(centered, key, g); err != nil {
+				return nil, fmt.Errorf("column %d, group %d: %w", c, g, err)
+			}
+		}
+	}
+
+	// Step 3: 1/σ of every group and column with one inverse square root
+	variances := make([]*HEData, 0, len(columns)*groups)
+	for c := range columns {
+		for g := range groups {
+			variances = append(variances, moments[c][g].moments[2])
+		}
+	}
+	invSigmas, err := e.BatchedInvSqrt(variances, B*B, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 4: Mean, variance, skewness and kurtosis of every group
+	stats := make([]*GroupStats, groups)
+	for g := range groups {
+		m := moments[0][g]
+		stat := &GroupStats{Variance: m.moments[2]}
+		var err error
+		if stat.Mean, err = e.Add(m.mean, means[0]); err != nil {
+			return nil, fmt.Errorf("mean of group %d: %w", g, err)
+		}
+		if stat.Skewness, err = e.standardize(m.moments[3], invSigmas[g], 3); err != nil {
+			return nil, fmt.Errorf("skewness of group %d: %w", g, err)
+		}
+		if stat.Kurtosis, err = e.standardize(m.moments[4], invSigmas[g], 4); err != nil {
+			return nil, fmt.Errorf("kurtosis of group %d: %w", g, err)
+		}
+		if stat.Kurtosis, err = e.SubConst(stat.Kurtosis, 3); err != nil {
+			return nil, fmt.Errorf("excess kurtosis of group %d: %w", g, err)
+		}
+		stats[g] = stat
+	}
+	if other == nil {
+		return stats, nil
+	}
+
+	// Step 5: Correlation E[dx·dy] × (1/σx) × (1/σy) of every group
+	for g := range groups {
+		cross, err := e.Mult(moments[0][g].deviations, moments[1][g].deviations)
+		if err != nil {
+			return nil, fmt.Errorf("cross deviations of group %d: %w", g, err)
+		}
+		cov, err := e.groupMean(cross, key, g)
+		if err != nil {
+			return nil, fmt.Errorf("covariance of group %d: %w", g, err)
+		}
+		invX, invY := invSigmas[g], invSigmas[groups+g]
+		if e.IsBTS {
+			if invX, err = e.DoBootstrap(invX, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (1/σx of group %d): %w", g, err)
+			}
+			if invY, err = e.DoBootstrap(invY, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (1/σy of group %d): %w", g, err)
+			}
+			if cov, err = e.DoBootstrap(cov, 1); err != nil {
+				return nil, fmt.Errorf("bootstrap (covariance of group %d): %w", g, err)
+			}
+		}
+		invXY, err := e.Mult(invX, invY)
+		if err != nil {
+			return nil, fmt.Errorf("1/(σx·σy) of group %d: %w", g, err)
+		}
+		if stats[g].Correlation, err = e.Mult(cov, invXY); err != nil {
+			return nil, fmt.Errorf("correlation of group %d: %w", g, err)
+		}
+	}
+	return stats, nil
+}
+
+// groupCentralMoments returns the mean of the centered column within group g, the deviations
+// from it and the central moments of order 2 to 4. With bootstrapping, the deviations are
+// refreshed for the mask, the power tree and the group mean, plus one level for packing the
+// variance in BatchedInvSqrt.
+func (e *HEEngine) groupCentralMoments(centered *HEData, key *groupKey, g int) (*groupMoments, error) {
+	const maxOrder = 4
+
+	// The group mean of the centered column
+	masked, err := e.groupMask(centered, key, g)
+	if err != nil {
+		return nil, fmt.Errorf("mask: %w", err)
+	}
+	mean, err := e.groupMean(masked, key, g)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	if mean, err = e.selectOneCtxt(mean); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (mean): %w", err)
+	}
+
+	// The deviations from the group mean, zero outside the group
+	extended, err := e.extendOneToMulty(mean, len(centered.Ciphertexts()), centered.Size())
+	if err != nil {
+		return nil, err
+	}
+	dev, err := e.Sub(centered, extended)
+	if err != nil {
+		return nil, fmt.Errorf("deviations: %w", err)
+	}
+	if e.IsBTS {
+		if dev, err = e.DoBootstrap(dev, powerDepth(maxOrder)+3); err != nil {
+			return nil, fmt.Errorf("bootstrap (deviations): %w", err)
+		}
+	}
+	if dev, err = e.groupMask(dev, key, g); err != nil {
+		return nil, fmt.Errorf("mask deviations: %w", err)
+	}
+
+	// The central moments E_g[d^k]
+	m := &groupMoments{mean: mean, deviations: dev, moments: map[int]*HEData{}}
+	powers := map[int]*HEData{}
+	for k := 2; k <= maxOrder; k++ {
+		dk, err := e.power(dev, k, powers)
+		if err != nil {
+			return nil, err
+		}
+		moment, err := e.groupMean(dk, key, g)
+		if err != nil {
+			return nil, fmt.Errorf("moment %d: %w", k, err)
+		}
+		if m.moments[k], err = e.selectOneCtxt(moment); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (moment %d): %w", k, err)
+		}
+	}
+	return m, nil
+}
+
+// groupMask zeroes the values of ct outside group g.
+func (e *HEEngine) groupMask(ct *HEData, key *groupKey, g int) (*HEData, error) {
+	if key.plain != nil {
+		return e.MultPlain(ct, key.plain[g])
+	}
+	return e.Mult(ct, key.masks[g])
+}
+
+// groupMean returns the mean within group g of ct, which must be zero outside the group. The sum
+// is multiplied by 1/n_g directly, so that it is never bootstrapped.
+func (e *HEEngine) groupMean(ct *HEData, key *groupKey, g int) (*HEData, error) {
+	sum, err := e.Sum(ct)
+	if err != nil {
+		return nil, fmt.Errorf("sum: %w", err)
+	}
+	if key.plain != nil {
+		return e.MultConst(sum, 1/key.counts[g])
+	}
+	invCount, err := e.extendOneToMulty(key.invCounts[g], len(sum.Ciphertexts()), sum.Size())
+	if err != nil {
+		return nil, err
+	}
+	return e.Mult(sum, invCount)
+}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// checkGroups compares the statistics of GroupBy with utils.GroupBy.
+func checkGroups(t *testing.T, e *HEEngine, name string, got []*GroupStats, want []utils.GroupSummary, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: %d groups, want %d", name, len(got), len(want))
+	}
+	for g, w := range want {
+		checkScalar(t, name+" count", decryptTest(t, e, got[g].Count, nil), w.Count, tol)
+		checkScalar(t, name+" mean", decryptTest(t, e, got[g].Mean, nil), w.Mean, tol)
+		checkScalar(t, name+" variance", decryptTest(t, e, got[g].Variance, nil), w.Variance, tol)
+		checkScalar(t, name+" skewness", decryptTest(t, e, got[g].Skewness, nil), w.Skewness, tol)
+		checkScalar(t, name+" kurtosis", decryptTest(t, e, got[g].Kurtosis, nil), w.Kurtosis, tol)
+		checkScalar(t, name+" correlation", decryptTest(t, e, got[g].Correlation, nil), w.Correlation, tol)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	e := testEngine(t)
+	values, labels := anovaData(2, 300)
+	other := normalData(3, 300, 0, 1)
+	for i := range other {
+		other[i] += 0.5 * values[i]
+	}
+	ctValues, ctOther := encryptTest(t, e, values), encryptTest(t, e, other)
+	_, want := utils.GroupBy(values, other, labels)
+
+	_, masks, err := e.EncryptOneHot(labels, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := e.GroupBy(ctValues, ctOther, masks, 5, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGroups(t, e, "GroupBy", stats, want, 1e-3)
+
+	_, groups := utils.OneHot(labels)
+	if stats, err = e.GroupByPlain(ctValues, ctOther, groups, 5, PPStat{}); err != nil {
+		t.Fatal(err)
+	}
+	checkGroups(t, e, "GroupByPlain", stats, want, 1e-3)
+}
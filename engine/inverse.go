@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// inverseMaxLogDegree bounds the Chebyshev degrees 2^k-1 tried for the initial guess of Inverse.
+const inverseMaxLogDegree = 8
+
+// inverseMaxIter bounds the number of Newton iterations of Inverse.
+const inverseMaxIter = 20
+
+// Inverse approximates 1/x for every x in ct, assuming lo ≤ x ≤ hi with 0 < lo < hi.
+//
+// The input is mapped onto [-1, 1], where a Chebyshev interpolant of 1/x gives an initial guess
+// y₀ with relative error ε₀ = max|1 - x·y₀|. The Newton iteration y ← y(2 - xy) (HENewtonInv)
+// then squares the relative error at every step. Among the degrees 2^k-1 for k ≤ 8, Inverse
+// picks the interpolant and iteration count that reach precision with the smallest total depth,
+// k+1 for the interpolant plus 2 per iteration, subject to the levels available. One more level
+// doubles the degree while an iteration costs two, so the interpolant does most of the work:
+// for precision 1e-6, hi/lo = 10 takes degree 31 and hi/lo = 1000 degree 255, both without
+// iterations. Newton steps are added when precision is beyond the interpolant or when the
+// levels cap the degree.
+//
+// precision is a bound on the relative error of the approximation itself. The CKKS noise is
+// absolute and adds to it, so the relative error grows towards hi (about 1e-5 at x = 1000 with
+// a 40-bit scale). 1/lo should stay within the range the bootstrapping tolerates. Without
+// bootstrapping, ct must have enough levels for the whole evaluation.
+func (e *HEEngine) Inverse(ct *HEData, lo, hi, precision float64) (*HEData, error) {
+	// With bootstrapping only the interpolant has to fit in a refreshed ciphertext, since
+	// HENewtonInv bootstraps before every iteration
+	maxDepth, newtonDepth := ct.Level(), 2
+	if e.IsBTS {
+		maxDepth, newtonDepth = e.params.MaxLevel(), 0
+	}
+	poly, iter, err := e.inverseParams(lo, hi, precision, maxDepth, newtonDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: Map [lo, hi] onto [-1, 1]; MultConst zeroes the unused slots
+	x := ct.CopyData()
+	if e.IsBTS {
+		if x, err = e.DoBootstrap(x, bits.Len(uint(poly.Degree()))+1); err != nil {
+			return nil, fmt.Errorf("bootstrap (input): %w", err)
+		}
+	}
+	u, err := e.SubConst(x, (hi+lo)/2)
+	if err != nil {
+		return nil, fmt.Errorf("center input: %w", err)
+	}
+	if u, err = e.MultConst(u, 2/(hi-lo)); err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+
+	// Step 2: Initial guess from the Chebyshev interpolant
+	polyEval := polynomial.NewEvaluator(e.params, e.Evaluator())
+	y, err := e.evalRealPoly(polyEval, u, polynomial.NewPolynomial(poly))
+	if err != nil {
+		return nil, fmt.Errorf("initial guess: %w", err)
+	}
+
+	// Step 3: Newton refinement y ← y(2 - xy)
+	if iter == 0 {
+		return y, nil
+	}
+	return e.HENewtonInv(x, y, 1, iter, 1)
+}
+
+// Divide approximates ct1/ct2 as ct1·Inverse(ct2), where lo ≤ ct2 ≤ hi with 0 < lo < hi.
+// precision bounds the relative error of the inverse (see Inverse).
+func (e *HEEngine) Divide(ct1, ct2 *HEData, lo, hi, precision float64) (*HEData, error) {
+	inv, err := e.Inverse(ct2, lo, hi, precision)
+	if err != nil {
+		return nil, fmt.Errorf("inverse: %w", err)
+	}
+	if e.IsBTS {
+		if inv, err = e.DoBootstrap(inv, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (inverse): %w", err)
+		}
+	}
+	return e.Mult(ct1, inv)
+}
+
+// inverseParams chooses the Chebyshev interpolant of 1/x on [lo, hi], expressed on [-1, 1], and
+// the number of Newton iterations that reach precision with the smallest depth. The interpolant
+// plus newtonDepth levels per iteration must fit in maxDepth. The candidate interpolants come
+// from the engine's polynomial cache with their relative error, so that only the first call on
+// [lo, hi] interpolates.
+func (e *HEEngine) inverseParams(lo, hi, precision float64, maxDepth, newtonDepth int) (bignum.Polynomial, int, error) {
+	if !(lo > 0 && hi > lo) {
+		return bignum.Polynomial{}, 0, fmt.Errorf("invalid inverse interval [%g, %g]", lo, hi)
+	}
+	if !(precision > 0 && precision < 1) {
+		return bignum.Polynomial{}, 0, fmt.Errorf("invalid inverse precision: %g", precision)
+	}
+
+	f := func(u float64) float64 {
+		return 2 / ((hi-lo)*u + hi + lo)
+	}
+
+	var best bignum.Polynomial
+	bestIter, bestDepth := 0, math.MaxInt
+	for k := 2; k <= inverseMaxLogDegree; k++ {
+		key := PolyKey{Func: "inverse", A: lo, B: hi, Nodes: 1 << k}
+		poly, eps, _ := e.Polys.PolyError(key, func() (bignum.Polynomial, float64, error) {
+			// Lattigo samples Nodes+1 Chebyshev points, so Nodes = 2^k-1 gives the degree 2^k-1
+			poly := GetChebyshevPoly(1.0, 1<<k-1, f)
+
+			// Relative error of the initial guess, sampled on the interval
+			eps := 0.0
+			for i := 0; i <= 1024; i++ {
+				u := -1 + float64(i)/512
+				y, _ := poly.Evaluate(u).Real().Float64()
+				eps = max(eps, math.Abs(1-y/f(u)))
+			}
+			return poly, eps, nil
+		})
+		if eps >= 1 {
+			continue
+		}
+
+		iter := 0
+		for err := eps; err > precision && iter < inverseMaxIter; err *= err {
+			iter++
+		}
+		if math.Pow(eps, math.Pow(2, float64(iter))) > precision {
+			continue
+		}
+
+		polyDepth := bits.Len(uint(poly.Degree())) + 1
+		if polyDepth+newtonDepth*iter > maxDepth {
+			continue
+		}
+		if depth := polyDepth + 2*iter; depth < bestDepth {
+			best, bestIter, bestDepth = poly, iter, depth
+		}
+	}
+
+	if bestDepth == math.MaxInt {
+		return bignum.Polynomial{}, 0, fmt.Errorf("cannot reach precision %g on [%g, %g] within %d levels", precision, lo, hi, maxDepth)
+	}
+	return best, bestIter, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 
 	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
@@ -13,6 +14,13 @@ import (
 // GetChebyshevPoly returns the Chebyshev polynomial approximation of f the
 // in the interval [-K, K] for the given degree.
 func GetChebyshevPoly(K float64, degree int, f64 func(x float64) (y float64)) bignum.Polynomial {
+	return chebyshevInterval(-K, K, degree+1, f64)
+}
+
+// chebyshevInterval returns the Chebyshev interpolant of f on [a, b] with the given number of
+// nodes, i.e. of degree nodes - 1. Lattigo's Interval.Nodes is the degree: it samples Nodes + 1
+// points.
+func chebyshevInterval(a, b float64, nodes int, f64 func(x float64) (y float64)) bignum.Polynomial {
 
 	FBig := func(x *big.Float) (y *big.Float) {
 		xF64, _ := x.Float64()
@@ -22,134 +30,108 @@ func GetChebyshevPoly(K float64, degree int, f64 func(x float64) (y float64)) bi
 	var prec uint = 128
 
 	interval := bignum.Interval{
-		A:     *bignum.NewFloat(-K, prec),
-		B:     *bignum.NewFloat(K, prec),
-		Nodes: degree,
+		A:     *bignum.NewFloat(a, prec),
+		B:     *bignum.NewFloat(b, prec),
+		Nodes: nodes - 1,
 	}
 	// Returns the polynomial.
 	return bignum.ChebyshevApproximation(FBig, interval)
 }
 
-func (e *HEEngine) ChebyshevInvSqrt(ct *HEData, mode int, B float64) (*HEData, error) {
-	cpData := ct.CopyData()
-	d := 9.0
-
-	var F func(float64) float64
-
+// invSqrtTarget returns the function interpolated on [-1, 1] by ChebyshevInvSqrt for an input
+// x ∈ (0, 2] shifted to x - 1: 1/√x, divided by √B (mode 2) or by √(B/2) (mode 1).
+func invSqrtTarget(mode int, B float64) (func(float64) float64, error) {
+	var scale float64
 	switch mode {
+	case 0:
+		scale = 1
 	case 1:
-		cpData, _ = e.MultConst(cpData, 2.0/B)
-		F = func(x float64) (y float64) {
-			if x > -1.0 {
-				return 1 / math.Sqrt(B/2) / (math.Sqrt(x + 1.0))
-			} else {
-				return 0
-			}
-		}
+		scale = 1 / math.Sqrt(B/2)
 	case 2:
-		F = func(x float64) (y float64) {
-			if x > -1.0 {
-				return 1 / math.Sqrt(B) / (math.Sqrt(x + 1.0))
-			} else {
-				return 0
-			}
-		}
-	case 0:
-		F = func(x float64) float64 {
-			if x > -1.0 {
-				return 1 / math.Sqrt(x+1.0)
-			}
-			return 0
-		}
+		scale = 1 / math.Sqrt(B)
 	default:
 		return nil, fmt.Errorf("invalid InvSqrt mode: %d", mode)
+	}
+	return func(x float64) float64 {
+		if x > -1.0 {
+			return scale / math.Sqrt(x+1.0)
+		}
+		return 0
+	}, nil
+}
+
+func (e *HEEngine) ChebyshevInvSqrt(ct *HEData, mode int, B float64) (*HEData, error) {
+	cpData := ct.CopyData()
+	d := 9.0
 
+	gcbsp, err := e.InvSqrtPoly(mode, B, int(math.Pow(2, float64(d))-1))
+	if err != nil {
+		return nil, err
+	}
+	if mode == 1 {
+		cpData, _ = e.MultConst(cpData, 2.0/B)
 	}
 
 	scaled_ct, err := e.SubConst(cpData, 1)
 	if err != nil {
 		return nil, err
 	}
-	gcbsp := GetChebyshevPoly(1.0, int(math.Pow(2, float64(d))-2), F)
 	poly := polynomial.NewPolynomial(gcbsp)
 	polyEval := polynomial.NewEvaluator(e.params, e.Evaluator())
+	return e.evalRealPoly(polyEval, scaled_ct, poly)
+}
+
+// ChebyshevInvSqrt_deg is ChebyshevInvSqrt with the Chebyshev interpolant of the given degree,
+// which consumes PolyDepth(degree) levels.
+func (e *HEEngine) ChebyshevInvSqrt_deg(ct *HEData, mode int, B float64, degree int) (*HEData, error) {
+	if degree < 1 {
+		return nil, fmt.Errorf("invalid InvSqrt degree: %d", degree)
+	}
+	gcbsp, err := e.InvSqrtPoly(mode, B, degree+1)
+	if err != nil {
+		return nil, err
+	}
+	return e.evalInvSqrtInit(ct, gcbsp, degree)
+}
 
-	scaledCtxts := scaled_ct.Ciphertexts()
-	invCtxts := []*rlwe.Ciphertext{}
-	targetScale := e.params.DefaultScale().Div(rlwe.NewScale(2))
-	for i := 0; i < len(scaledCtxts); i++ {
-		p2, _ := polyEval.Evaluate(scaledCtxts[i], poly, targetScale)
-		p2.Scale = p2.Scale.Mul(rlwe.NewScale(2))
-		conj, _ := e.evaluator.ConjugateNew(p2)
-		e.evaluator.Add(p2, conj, p2)
-		p2.Scale = scaledCtxts[i].Scale
-		invCtxts = append(invCtxts, p2)
+// RemezInvSqrt_deg is ChebyshevInvSqrt_deg with the minimax polynomial of the given degree in
+// relative error on [min, 2] (see RemezPoly). Its relative error is bounded on the domain by the
+// error returned by RemezInvSqrtPoly, including close to the singularity at 0 where the
+// Chebyshev interpolant on (0, 2] is poor.
+func (e *HEEngine) RemezInvSqrt_deg(ct *HEData, mode int, B float64, degree int, min float64) (*HEData, error) {
+	if degree < 1 {
+		return nil, fmt.Errorf("invalid InvSqrt degree: %d", degree)
+	}
+	poly, _, err := e.RemezInvSqrtPoly(mode, B, degree, min)
+	if err != nil {
+		return nil, err
 	}
-	return NewHEData(invCtxts, ct.Size(), invCtxts[0].Level(), ct.Scale()), nil
+	return e.evalInvSqrtInit(ct, poly, degree)
 }
 
-func (e *HEEngine) ChebyshevInvSqrt_deg(ct *HEData, mode int, B float64, deg float64) (*HEData, error) {
+// PolyDepth returns the multiplicative depth consumed by the evaluation of a polynomial of the
+// given degree, ⌈log₂(degree + 1)⌉.
+func PolyDepth(degree int) int {
+	return bits.Len(uint(degree))
+}
+
+// evalInvSqrtInit evaluates the initial guess poly of the given degree on ct - 1.
+func (e *HEEngine) evalInvSqrtInit(ct *HEData, gcbsp bignum.Polynomial, degree int) (*HEData, error) {
 	
 	cpData := ct.CopyData()
-	if cpData.Level() - int(deg) < 0 {
+	if cpData.Level() - PolyDepth(degree) < 0 {
 		if e.IsBTS {
 			cpData, _ = e.DoBootstrap(cpData, e.params.MaxLevel())
 		}
 	}
-	
-	d := deg
-
-	var F func(float64) float64
-
-	switch mode {
-	case 1:
-		F = func(x float64) (y float64) {
-			if x > -1.0 {
-				return 1 / math.Sqrt(B/2) / (math.Sqrt(x + 1.0))
-			} else {
-				return 0
-			}
-		}
-	case 2:
-		F = func(x float64) (y float64) {
-			if x > -1.0 {
-				return 1 / math.Sqrt(B) / (math.Sqrt(x + 1.0))
-			} else {
-				return 0
-			}
-		}
-	case 0:
-		F = func(x float64) float64 {
-			if x > -1.0 {
-				return 1 / math.Sqrt(x+1.0)
-			}
-			return 0
-		}
-	default:
-		return nil, fmt.Errorf("invalid InvSqrt mode: %d", mode)
-
-	}
 
 	scaled_ct, err := e.SubConst(cpData, 1)
 	if err != nil {
 		return nil, err
 	}
-	gcbsp := GetChebyshevPoly(1.0, int(math.Pow(2, float64(d))-2), F)
 	poly := polynomial.NewPolynomial(gcbsp)
 	polyEval := polynomial.NewEvaluator(e.params, e.Evaluator())
-
-	scaledCtxts := scaled_ct.Ciphertexts()
-	invCtxts := []*rlwe.Ciphertext{}
-	targetScale := e.params.DefaultScale().Div(rlwe.NewScale(2))
-	for i := 0; i < len(scaledCtxts); i++ {
-		p2, _ := polyEval.Evaluate(scaledCtxts[i], poly, targetScale)
-		p2.Scale = p2.Scale.Mul(rlwe.NewScale(2))
-		conj, _ := e.evaluator.ConjugateNew(p2)
-		e.evaluator.Add(p2, conj, p2)
-		p2.Scale = scaledCtxts[i].Scale
-		invCtxts = append(invCtxts, p2)
-	}
-	return NewHEData(invCtxts, ct.Size(), invCtxts[0].Level(), ct.Scale()), nil
+	return e.evalRealPoly(polyEval, scaled_ct, poly)
 }
 
 func (e *HEEngine) HENewtonInv(ct, init *HEData, B float64, iter, mode int) (*HEData, error) {
@@ -186,12 +168,278 @@ func (e *HEEngine) HENewtonInv(ct, init *HEData, B float64, iter, mode int) (*HE
 	return y, nil
 }
 
-func (e *HEEngine) CryptoInvSqrt(ct *HEData, scaled_ct *HEData, B float64, deg float64, iter int, cheb_mode int, nt_mode int) (*HEData, error) {
-	
-	y, err := e.ChebyshevInvSqrt_deg(scaled_ct, cheb_mode, B, deg)
+// InvSqrtInit selects the polynomial of the initial guess of CryptoInvSqrt.
+type InvSqrtInit int
+
+const (
+	// ChebyshevInit interpolates 1/√x on (0, 2] (ChebyshevInvSqrt_deg).
+	ChebyshevInit InvSqrtInit = iota
+	// RemezInit uses the minimax polynomial in relative error on [RemezInvSqrtMin, 2]
+	// (RemezInvSqrt_deg).
+	RemezInit
+)
+
+// RemezInvSqrtMin is the lower end of the scaled inputs on which RemezInit bounds the relative
+// error of the initial guess.
+const RemezInvSqrtMin = 1.0 / 32768
+
+func (e *HEEngine) CryptoInvSqrt(ct *HEData, scaled_ct *HEData, B float64, degree int, iter int, cheb_mode int, nt_mode int, init InvSqrtInit) (*HEData, error) {
+	y, err := e.invSqrtInitGuess(scaled_ct, cheb_mode, B, degree, init)
 	if err != nil {
 		return y, err
 	}
 
 	return e.HENewtonInv(ct, y, B, iter, nt_mode)
-}
\ No newline at end of file
+}
+
+// invSqrtInitGuess evaluates the initial guess init of the given degree on scaled_ct.
+func (e *HEEngine) invSqrtInitGuess(scaled_ct *HEData, cheb_mode int, B float64, degree int, init InvSqrtInit) (*HEData, error) {
+	switch init {
+	case ChebyshevInit:
+		return e.ChebyshevInvSqrt_deg(scaled_ct, cheb_mode, B, degree)
+	case RemezInit:
+		return e.RemezInvSqrt_deg(scaled_ct, cheb_mode, B, degree, RemezInvSqrtMin)
+	default:
+		return nil, fmt.Errorf("invalid InvSqrt initial guess: %d", init)
+	}
+}
+
+// InvSqrtRefine selects the iteration refining the initial guess of the inverse square root.
+type InvSqrtRefine int
+
+const (
+	// NewtonRefine runs y ← y(3 - xy²)/2 (HENewtonInv, CryptoInvSqrt).
+	NewtonRefine InvSqrtRefine = iota
+	// GoldschmidtRefine runs the coupled iteration of HEGoldschmidt (CryptoGoldschmidt), which
+	// also yields √x.
+	GoldschmidtRefine
+	// FusedNewtonRefine runs the Newton iteration of NewtonRefine with HENewtonInvFused.
+	FusedNewtonRefine
+)
+
+// HEGoldschmidt refines init ≈ 1/√x with iter Goldschmidt iterations and returns √x and 1/√x,
+// where x is 2·ct (mode 2) or B·ct (mode 3) as in HENewtonInv.
+//
+// Starting from g = x·y/2 and h = y, every iteration computes r = g·h - 1/2 and updates
+// g ← g - g·r and h ← h - h·r. The ratio g/h = x/2 is kept while g·h converges quadratically to
+// 1/2, so g tends to √x/2 and h to 1/√x. An iteration consumes two levels like a Newton step, but
+// its two products by r are independent, and √x comes without a final multiplication by x.
+//
+// The ratio is not self-correcting: an error of g or h would persist through the iterations.
+// With bootstrapping only h is refreshed, and g is recomputed as x·h/2 at the cost of one more
+// level, so that the bootstrapping error of h is corrected like in a Newton step.
+func (e *HEEngine) HEGoldschmidt(ct, init *HEData, B float64, iter, mode int) (sqrt, invSqrt *HEData, err error) {
+	// Step 1: x/2
+	halfX := ct.CopyData()
+	switch mode {
+	case 2:
+	case 3:
+		if halfX, err = e.MultConst(halfX, B/2); err != nil {
+			return nil, nil, fmt.Errorf("scale input: %w", err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid Goldschmidt mode: %d", mode)
+	}
+	h := init.CopyData()
+	if e.IsBTS {
+		if halfX, err = e.DoBootstrap(halfX, 3); err != nil {
+			return nil, nil, fmt.Errorf("bootstrap (input): %w", err)
+		}
+		if h, err = e.DoBootstrap(h, 1); err != nil {
+			return nil, nil, fmt.Errorf("bootstrap (initial guess): %w", err)
+		}
+	}
+
+	// Step 2: g = x·y/2, h = y
+	g, err := e.Mult(halfX, h)
+	if err != nil {
+		return nil, nil, fmt.Errorf("x·y/2: %w", err)
+	}
+
+	// Step 3: g ← g - g·r, h ← h - h·r with r = g·h - 1/2
+	for it := range iter {
+		if e.IsBTS && g.Level() < 2 {
+			if h, err = e.DoBootstrap(h, 3); err != nil {
+				return nil, nil, fmt.Errorf("bootstrap (iteration %d): %w", it+1, err)
+			}
+			if g, err = e.Mult(halfX, h); err != nil {
+				return nil, nil, fmt.Errorf("x·h/2 (iteration %d): %w", it+1, err)
+			}
+		}
+		r, err := e.Mult(g, h)
+		if err != nil {
+			return nil, nil, fmt.Errorf("g·h (iteration %d): %w", it+1, err)
+		}
+		if r, err = e.SubConst(r, 0.5); err != nil {
+			return nil, nil, fmt.Errorf("r (iteration %d): %w", it+1, err)
+		}
+		gr, err := e.Mult(g, r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("g·r (iteration %d): %w", it+1, err)
+		}
+		hr, err := e.Mult(h, r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("h·r (iteration %d): %w", it+1, err)
+		}
+		if g, err = e.Sub(g, gr); err != nil {
+			return nil, nil, fmt.Errorf("update g (iteration %d): %w", it+1, err)
+		}
+		if h, err = e.Sub(h, hr); err != nil {
+			return nil, nil, fmt.Errorf("update h (iteration %d): %w", it+1, err)
+		}
+	}
+
+	// Step 4: √x = 2g
+	if sqrt, err = e.Add(g, g); err != nil {
+		return nil, nil, fmt.Errorf("√x: %w", err)
+	}
+	return sqrt, h, nil
+}
+
+// CryptoGoldschmidt is CryptoInvSqrt with the Newton iteration replaced by iter Goldschmidt
+// iterations (HEGoldschmidt, gs_mode 2 or 3), returning √x along with 1/√x.
+func (e *HEEngine) CryptoGoldschmidt(ct *HEData, scaled_ct *HEData, B float64, degree int, iter int, cheb_mode int, gs_mode int, init InvSqrtInit) (sqrt, invSqrt *HEData, err error) {
+	y, err := e.invSqrtInitGuess(scaled_ct, cheb_mode, B, degree, init)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return e.HEGoldschmidt(ct, y, B, iter, gs_mode)
+}
+
+// HENewtonInvFused runs the iterations of HENewtonInv with the same modes 0 to 3 and result, each
+// fused into a single NewtonStep: mode 0 refines 1/x without scaling the input. With bootstrapping, y is refreshed when fewer than two levels remain,
+// and x whenever it falls below the level of y, so that a low-level input does not force a
+// bootstrapping of y at every iteration.
+func (e *HEEngine) HENewtonInvFused(ct, init *HEData, B float64, iter, mode int) (*HEData, error) {
+	var err error
+	N := 1.0
+	x, y := ct, init
+	switch mode {
+	case 0:
+	case 1:
+		if x, err = e.MultConst(x, B); err != nil {
+			return nil, fmt.Errorf("scale input: %w", err)
+		}
+	case 2:
+		N = 2
+	case 3:
+		N = 2
+		if x, err = e.MultConst(x, B/N); err != nil {
+			return nil, fmt.Errorf("scale input: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid Newton mode: %d", mode)
+	}
+
+	for it := range iter {
+		if e.IsBTS {
+			if y, err = e.DoBootstrap(y, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (iteration %d): %w", it+1, err)
+			}
+			if x, err = e.DoBootstrap(x, y.Level()); err != nil {
+				return nil, fmt.Errorf("bootstrap (input, iteration %d): %w", it+1, err)
+			}
+		}
+		if y, err = e.NewtonStep(x, y, N); err != nil {
+			return nil, fmt.Errorf("iteration %d: %w", it+1, err)
+		}
+	}
+	return y, nil
+}
+
+// NewtonStep computes one Newton iteration y ← ((N+1)/N)·y - x·y·y^N, for 1/x (N = 1) or 1/√(2x)
+// (N = 2), in two levels.
+//
+// x·y and y^N are computed in parallel, and their product is kept at the squared scale: the term
+// ((N+1)/N)·y is added there before the single rescaling, with the constant folded into the
+// plaintext scale, so that it costs neither a level nor a rescaling. Unlike Mult, the inputs are
+// not copied. The unused slots of y are multiplied by 0 as in MultConst.
+func (e *HEEngine) NewtonStep(x, y *HEData, N float64) (*HEData, error) {
+	if N != 1 && N != 2 {
+		return nil, fmt.Errorf("invalid Newton exponent: %g", N)
+	}
+	if x.Scale() != y.Scale() {
+		return nil, fmt.Errorf("scale mismatch: %f vs %f", x.Scale(), y.Scale())
+	}
+	level := min(x.Level(), y.Level())
+	if level < 2 {
+		return nil, fmt.Errorf("level cannot be smaller than 2")
+	}
+
+	eval := e.Evaluator()
+	xs, ys := x.Ciphertexts(), y.Ciphertexts()
+	slots := e.params.MaxSlots()
+	ctNum := min(len(xs), len(ys))
+	result := make([]*rlwe.Ciphertext, ctNum)
+	c := (N + 1) / N
+	for i := range ctNum {
+		// Step 1: -x·y and y^N
+		u, err := eval.MulRelinNew(xs[i], ys[i])
+		if err != nil {
+			return nil, fmt.Errorf("x·y failed at index %d: %w", i, err)
+		}
+		if err = eval.Mul(u, -1, u); err != nil {
+			return nil, fmt.Errorf("negation failed at index %d: %w", i, err)
+		}
+		if err = eval.Rescale(u, u); err != nil {
+			return nil, fmt.Errorf("Rescale failed at index %d: %w", i, err)
+		}
+		v := ys[i]
+		if N == 2 {
+			if v, err = eval.MulRelinNew(ys[i], ys[i]); err != nil {
+				return nil, fmt.Errorf("y² failed at index %d: %w", i, err)
+			}
+			if err = eval.Rescale(v, v); err != nil {
+				return nil, fmt.Errorf("Rescale failed at index %d: %w", i, err)
+			}
+		}
+
+		// Step 2: -x·y·y^N + c·y at the squared scale, then a single rescaling
+		w, err := eval.MulRelinNew(u, v)
+		if err != nil {
+			return nil, fmt.Errorf("x·y·y^N failed at index %d: %w", i, err)
+		}
+		if valid := y.Size() - i*slots; valid >= slots {
+			err = eval.MulThenAdd(ys[i], c, w)
+		} else {
+			consts := make([]float64, slots)
+			for j := range valid {
+				consts[j] = c
+			}
+			err = eval.MulThenAdd(ys[i], consts, w)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("MulThenAdd failed at index %d: %w", i, err)
+		}
+		if err = eval.Rescale(w, w); err != nil {
+			return nil, fmt.Errorf("Rescale failed at index %d: %w", i, err)
+		}
+		result[i] = w
+	}
+
+	return NewHEData(result, min(x.Size(), y.Size()), level-2, y.Scale()), nil
+}
+
+// Sqrt approximates √x for 0 ≤ x ≤ interval as x·(1/√x), with 1/√x evaluated by the strategy s
+// with B = interval/2, so that x/B ∈ [0, 2]. The relative error degrades for x close to 0, where
+// 1/√x has no good polynomial approximation.
+func (e *HEEngine) Sqrt(ct *HEData, interval float64, s InvSqrtStrategy) (*HEData, error) {
+	if !(interval > 0) {
+		return nil, fmt.Errorf("invalid Sqrt interval: %g", interval)
+	}
+
+	// Step 1: 1/√x
+	invSqrt, err := s.InvSqrt(e, ct, interval/2)
+	if err != nil {
+		return nil, err
+	}
+	if e.IsBTS {
+		if invSqrt, err = e.DoBootstrap(invSqrt, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/√x): %w", err)
+		}
+	}
+
+	// Step 2: √x = x × (1/√x)
+	return e.Mult(ct, invSqrt)
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// invSqrtInputs encrypts x/B for the initial guess and x/2 for the refinement.
+func invSqrtInputs(t *testing.T, e *HEEngine, x []float64, B float64) (scaled, half *HEData) {
+	t.Helper()
+	s, h := make([]float64, len(x)), make([]float64, len(x))
+	for i, v := range x {
+		s[i], h[i] = v/B, v/2
+	}
+	return encryptTest(t, e, s), encryptTest(t, e, h)
+}
+
+func TestGoldschmidt(t *testing.T) {
+	e := testEngine(t)
+	x := utils.Linspace(0.5, 20, 64)
+	wantSqrt := make([]float64, len(x))
+	for i, v := range x {
+		wantSqrt[i] = math.Sqrt(v)
+	}
+	scaled, half := invSqrtInputs(t, e, x, 10)
+
+	sqrt, invSqrt, err := e.CryptoGoldschmidt(half, scaled, 10, 126, 6, 2, 2, ChebyshevInit)
+	checkClose(t, "√x", decryptTest(t, e, sqrt, err), wantSqrt, 1e-4)
+	checkClose(t, "1/√x", decryptTest(t, e, invSqrt, nil), invSqrtWant(x), 1e-4)
+
+	// Mode 3 refines on x/B
+	sqrt, invSqrt, err = e.CryptoGoldschmidt(scaled, scaled, 10, 126, 6, 2, 3, ChebyshevInit)
+	checkClose(t, "√x (mode 3)", decryptTest(t, e, sqrt, err), wantSqrt, 1e-4)
+	checkClose(t, "1/√x (mode 3)", decryptTest(t, e, invSqrt, nil), invSqrtWant(x), 1e-4)
+}
+
+func TestGoldschmidtBTS(t *testing.T) {
+	e := testBTSEngine(t)
+	// Every slot is used: the guess diverges on unused slots, which bootstrapping spreads
+	x := utils.Linspace(0.5, 20, e.Slots)
+	scaled, half := invSqrtInputs(t, e, x, 10)
+
+	// The iterations outlast the levels of g, which is recomputed from the bootstrapped h
+	_, invSqrt, err := e.CryptoGoldschmidt(half, scaled, 10, 126, 6, 2, 2, ChebyshevInit)
+	checkClose(t, "1/√x", decryptTest(t, e, invSqrt, err), invSqrtWant(x), 1e-4)
+}
+
+// checkFusedNewton runs HENewtonInv and HENewtonInvFused one iteration at a time from the same
+// Chebyshev guess of the given degree, and compares their MRE at every iteration up to iMax: the
+// MREs agree while the iteration converges, and the fused one never exceeds the other at the
+// noise floor, which it lowers by rescaling less often.
+func checkFusedNewton(t *testing.T, e *HEEngine, x []float64, B float64, degree, iMax int) {
+	t.Helper()
+	want := invSqrtWant(x)
+	scaled, half := invSqrtInputs(t, e, x, B)
+	guess, err := e.ChebyshevInvSqrt_deg(scaled, 2, B, degree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, fused := guess, guess
+	for i := 1; i <= iMax; i++ {
+		if ref, err = e.HENewtonInv(half, ref, B, 1, 2); err != nil {
+			t.Fatal(err)
+		}
+		if fused, err = e.HENewtonInvFused(half, fused, B, 1, 2); err != nil {
+			t.Fatal(err)
+		}
+		_, refMRE := utils.CheckMRE(decryptTest(t, e, ref, nil), x, want, len(x))
+		_, fusedMRE := utils.CheckMRE(decryptTest(t, e, fused, nil), x, want, len(x))
+		converging := refMRE >= 1e-4 && math.Abs(fusedMRE-refMRE) > 1e-2*refMRE
+		if converging || fusedMRE > 1.01*refMRE+1e-9 {
+			t.Errorf("iteration %d: MRE %.3e fused, %.3e with HENewtonInv", i, fusedMRE, refMRE)
+		}
+	}
+}
+
+func TestNewtonFused(t *testing.T) {
+	checkFusedNewton(t, testEngine(t), utils.Linspace(0.05, 20, 64), 10, 14, 8)
+}
+
+func TestNewtonFusedInverse(t *testing.T) {
+	e := testEngine(t)
+	x := utils.Linspace(0.5, 1.5, 64)
+	want := make([]float64, len(x))
+	for i := range want {
+		want[i] = 1 / x[i]
+	}
+	ct, ones := encryptTest(t, e, x), encryptTest(t, e, utils.Linspace(1, 1, len(x)))
+
+	// Mode 0 refines 1/x from y₀ = 1, like HENewtonInv
+	ref, err := e.HENewtonInv(ct, ones, 1, 6, 0)
+	checkClose(t, "HENewtonInv 1/x", decryptTest(t, e, ref, err), want, 1e-6)
+	fused, err := e.HENewtonInvFused(ct, ones, 1, 6, 0)
+	checkClose(t, "HENewtonInvFused 1/x", decryptTest(t, e, fused, err), want, 1e-6)
+
+	if _, err := e.HENewtonInvFused(ct, ones, 1, 1, 4); err == nil {
+		t.Error("HENewtonInvFused: expected an error for mode 4")
+	}
+}
+
+func TestNewtonFusedBTS(t *testing.T) {
+	e := testBTSEngine(t)
+	checkFusedNewton(t, e, utils.Linspace(0.05, 20, e.Slots), 10, 14, 6)
+}
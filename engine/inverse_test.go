@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestInverse(t *testing.T) {
+	e := testEngine(t)
+	for _, tc := range []struct {
+		lo, hi float64
+		n      int
+	}{
+		{1, 100, 300},
+		// 2/(hi - lo) = 1 on full ciphertexts takes the scalar path of MultConst
+		{1, 3, 2 * e.Slots},
+	} {
+		x := utils.Linspace(tc.lo, tc.hi, tc.n)
+		inv, err := e.Inverse(encryptTest(t, e, x), tc.lo, tc.hi, 1e-6)
+		got := decryptTest(t, e, inv, err)
+		checkClose(t, "1/x", got, utils.Inverse(x), 1e-5)
+	}
+}
+
+func TestDivide(t *testing.T) {
+	e := testEngine(t)
+	x := normalData(1, 100, 0, 10)
+	y := utils.Linspace(2, 50, 100)
+	want := make([]float64, len(x))
+	for i := range want {
+		want[i] = x[i] / y[i]
+	}
+
+	q, err := e.Divide(encryptTest(t, e, x), encryptTest(t, e, y), 2, 50, 1e-6)
+	checkClose(t, "x/y", decryptTest(t, e, q, err), want, 1e-5)
+}
+
+func TestInverseParamsDegree(t *testing.T) {
+	e := testEngine(t)
+	// 2^k-1 nodes give the interpolant of degree 2^k-1 (see the example of Inverse)
+	for _, tc := range []struct {
+		hi     float64
+		degree int
+	}{{10, 31}, {1000, 255}} {
+		poly, iter, err := e.inverseParams(1, tc.hi, 1e-6, 100, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if poly.Degree() != tc.degree || iter != 0 {
+			t.Errorf("hi/lo = %g: degree %d with %d iterations, want %d without", tc.hi, poly.Degree(), iter, tc.degree)
+		}
+
+		// One level less, with bootstrapped iterations, reuses the cached interpolants
+		cached := e.Polys.Len()
+		if poly, iter, err = e.inverseParams(1, tc.hi, 1e-6, bits.Len(uint(tc.degree)), 0); err != nil {
+			t.Fatal(err)
+		}
+		if poly.Degree() >= tc.degree || iter == 0 {
+			t.Errorf("hi/lo = %g in %d levels: degree %d with %d iterations, want a lower degree with iterations", tc.hi, bits.Len(uint(tc.degree)), poly.Degree(), iter)
+		}
+		if got := e.Polys.Len(); got != cached {
+			t.Errorf("hi/lo = %g: cache grew from %d to %d polynomials", tc.hi, cached, got)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// SigmoidApprox is a Chebyshev interpolant of the logistic function σ(x) = 1/(1 + e^-x) on
+// [-Interval, Interval] (see ApproxSigmoid). Outside the interval the polynomial diverges quickly,
+// so Interval must bound every input: for logistic regression on standardized features,
+// |b + Σ wⱼxⱼ|.
+type SigmoidApprox struct {
+	Interval float64
+	Degree   int
+	fn       *ApproxFunction
+}
+
+// NewSigmoidApprox interpolates σ on [-interval, interval] with the given degree. A degree of
+// 2^k-1 uses all the k levels of the evaluation; one more level maps the input onto [-1, 1].
+func NewSigmoidApprox(interval float64, degree int) (*SigmoidApprox, error) {
+	if !(interval > 0) {
+		return nil, fmt.Errorf("invalid sigmoid interval: %g", interval)
+	}
+	fn, err := ApproxSigmoid(interval, degree)
+	if err != nil {
+		return nil, err
+	}
+	return &SigmoidApprox{Interval: interval, Degree: degree, fn: fn}, nil
+}
+
+// depth returns the levels consumed by Sigmoid.
+func (s *SigmoidApprox) depth() int {
+	return s.fn.Depth()
+}
+
+// Sigmoid approximates σ(x) for every x in ct with the interpolant s.
+func (e *HEEngine) Sigmoid(ct *HEData, s *SigmoidApprox) (*HEData, error) {
+	return e.EvalApprox(ct, s.fn)
+}
+
+// LogisticModel is an encrypted logistic regression model P(y = 1) = σ(Bias + Σⱼ Weights[j]·xⱼ).
+// Every field holds its value replicated in all slots of a single ciphertext.
+type LogisticModel struct {
+	Weights []*HEData
+	Bias    *HEData
+}
+
+// LogisticRegression trains a logistic regression of the 0/1 labels y on the columns X with
+// epochs steps of full-batch gradient descent on the mean log-loss,
+//
+//	wⱼ ← wⱼ - rate·(1/n)·Σᵢ (σ(zᵢ) - yᵢ)·xᵢⱼ,  b ← b - rate·(1/n)·Σᵢ (σ(zᵢ) - yᵢ),
+//
+// where zᵢ = b + Σⱼ wⱼxᵢⱼ and σ is the interpolant sigmoid. The weights start at zero. The
+// columns should be standardized (see ZScoreNorm) so that a moderate sigmoid.Interval bounds z
+// throughout the training. With bootstrapping, z and the model are refreshed at every epoch;
+// without it, the input levels must cover all the epochs.
+func (e *HEEngine) LogisticRegression(X []*HEData, y *HEData, sigmoid *SigmoidApprox, epochs int, rate float64) (*LogisticModel, error) {
+	if len(X) == 0 {
+		return nil, fmt.Errorf("no feature columns")
+	}
+	if epochs < 1 {
+		return nil, fmt.Errorf("invalid epoch count: %d", epochs)
+	}
+	for j := range X {
+		if X[j].Size() != y.Size() {
+			return nil, fmt.Errorf("size mismatch in column %d: %d vs %d", j, X[j].Size(), y.Size())
+		}
+	}
+
+	// Step 1: Zero weights and bias
+	zeros, err := e.Encrypt(make([]float64, e.params.MaxSlots()), e.params.MaxLevel())
+	if err != nil {
+		return nil, fmt.Errorf("encrypt initial weights: %w", err)
+	}
+	model := &LogisticModel{Weights: make([]*HEData, len(X)), Bias: zeros}
+	for j := range model.Weights {
+		model.Weights[j] = zeros.CopyData()
+	}
+
+	for epoch := range epochs {
+		// Step 2: σ(z) - y, scaled by rate/n; MultConst zeroes the unused slots
+		if e.IsBTS {
+			if model, err = e.refreshLogisticModel(model); err != nil {
+				return nil, fmt.Errorf("epoch %d: %w", epoch, err)
+			}
+		}
+		z, err := e.logisticLogits(model, X)
+		if err != nil {
+			return nil, fmt.Errorf("logits (epoch %d): %w", epoch, err)
+		}
+		if e.IsBTS {
+			// Two more levels for the residual and the gradient
+			if z, err = e.DoBootstrap(z, sigmoid.depth()+2); err != nil {
+				return nil, fmt.Errorf("bootstrap (logits, epoch %d): %w", epoch, err)
+			}
+		}
+		p, err := e.Sigmoid(z, sigmoid)
+		if err != nil {
+			return nil, fmt.Errorf("sigmoid (epoch %d): %w", epoch, err)
+		}
+		residual, err := e.Sub(p, y)
+		if err != nil {
+			return nil, fmt.Errorf("residual (epoch %d): %w", epoch, err)
+		}
+		if residual, err = e.MultConst(residual, rate/float64(y.Size())); err != nil {
+			return nil, fmt.Errorf("scale residual (epoch %d): %w", epoch, err)
+		}
+
+		// Step 3: Gradient step
+		for j := range X {
+			grad, err := e.InnerProduct(residual, X[j])
+			if err != nil {
+				return nil, fmt.Errorf("gradient %d (epoch %d): %w", j, epoch, err)
+			}
+			if grad, err = e.selectOneCtxt(grad); err != nil {
+				return nil, fmt.Errorf("selectOneCtxt (gradient %d, epoch %d): %w", j, epoch, err)
+			}
+			if model.Weights[j], err = e.Sub(model.Weights[j], grad); err != nil {
+				return nil, fmt.Errorf("update weight %d (epoch %d): %w", j, epoch, err)
+			}
+		}
+		grad, err := e.Sum(residual)
+		if err != nil {
+			return nil, fmt.Errorf("bias gradient (epoch %d): %w", epoch, err)
+		}
+		if grad, err = e.selectOneCtxt(grad); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (bias gradient, epoch %d): %w", epoch, err)
+		}
+		if model.Bias, err = e.Sub(model.Bias, grad); err != nil {
+			return nil, fmt.Errorf("update bias (epoch %d): %w", epoch, err)
+		}
+	}
+
+	if e.IsBTS {
+		return e.refreshLogisticModel(model)
+	}
+	return model, nil
+}
+
+// LogisticScore returns the encrypted probabilities σ(b + Σⱼ wⱼxⱼ) of the rows given by the
+// columns X under model.
+func (e *HEEngine) LogisticScore(model *LogisticModel, X []*HEData, sigmoid *SigmoidApprox) (*HEData, error) {
+	if len(X) != len(model.Weights) {
+		return nil, fmt.Errorf("model has %d weights, got %d columns", len(model.Weights), len(X))
+	}
+	var err error
+	if e.IsBTS {
+		if model, err = e.refreshLogisticModel(model); err != nil {
+			return nil, err
+		}
+	}
+	z, err := e.logisticLogits(model, X)
+	if err != nil {
+		return nil, fmt.Errorf("logits: %w", err)
+	}
+	return e.Sigmoid(z, sigmoid)
+}
+
+// logisticLogits returns z = b + Σⱼ wⱼxⱼ for every row of the columns X.
+func (e *HEEngine) logisticLogits(model *LogisticModel, X []*HEData) (*HEData, error) {
+	num, size := len(X[0].Ciphertexts()), X[0].Size()
+	bias, err := e.extendOneToMulty(model.Bias, num, size)
+	if err != nil {
+		return nil, err
+	}
+	z := bias
+	for j := range X {
+		w, err := e.extendOneToMulty(model.Weights[j], num, size)
+		if err != nil {
+			return nil, err
+		}
+		term, err := e.Mult(X[j], w)
+		if err != nil {
+			return nil, fmt.Errorf("w·x (%d): %w", j, err)
+		}
+		if z, err = e.Add(z, term); err != nil {
+			return nil, fmt.Errorf("accumulate (%d): %w", j, err)
+		}
+	}
+	return z, nil
+}
+
+// refreshLogisticModel returns a copy of model with the weights and the bias bootstrapped to the
+// highest level.
+func (e *HEEngine) refreshLogisticModel(model *LogisticModel) (*LogisticModel, error) {
+	refreshed := &LogisticModel{Weights: make([]*HEData, len(model.Weights))}
+	var err error
+	for j, w := range model.Weights {
+		if refreshed.Weights[j], err = e.DoBootstrap(w, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (weight %d): %w", j, err)
+		}
+	}
+	if refreshed.Bias, err = e.DoBootstrap(model.Bias, e.params.MaxLevel()); err != nil {
+		return nil, fmt.Errorf("bootstrap (bias): %w", err)
+	}
+	return refreshed, nil
+}
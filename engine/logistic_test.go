@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestLogisticRegression(t *testing.T) {
+	e := testEngine(t)
+	x1 := normalData(1, 200, 0, 1)
+	x2 := normalData(2, 200, 0, 1)
+	noise := normalData(3, 200, 0, 1)
+	y := make([]float64, len(x1))
+	for i := range y {
+		if 1.5*x1[i]-x2[i]+noise[i] > 0 {
+			y[i] = 1
+		}
+	}
+	X := []*HEData{encryptTest(t, e, x1), encryptTest(t, e, x2)}
+
+	sigmoid, err := NewSigmoidApprox(8, 31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := e.LogisticRegression(X, encryptTest(t, e, y), sigmoid, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights, bias := utils.LogisticRegression([][]float64{x1, x2}, y, 3, 1)
+	for j, want := range weights {
+		checkScalar(t, "weight", decryptTest(t, e, model.Weights[j], nil), want, 1e-3)
+	}
+	checkScalar(t, "bias", decryptTest(t, e, model.Bias, nil), bias, 1e-3)
+
+	score, err := e.LogisticScore(model, X, sigmoid)
+	want := make([]float64, len(y))
+	for i := range want {
+		want[i] = 1 / (1 + math.Exp(-(bias + weights[0]*x1[i] + weights[1]*x2[i])))
+	}
+	checkClose(t, "score", decryptTest(t, e, score, err), want, 1e-3)
+}
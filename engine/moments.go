@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// powerDepth returns the levels consumed by power for the exponent k: ⌈log2 k⌉.
+func powerDepth(k int) int {
+	return bits.Len(uint(k - 1))
+}
+
+// power returns x^k, k ≥ 1, with the depth-optimal product tree x^k = x^⌈k/2⌉ · x^⌊k/2⌋. The
+// intermediate powers are shared through cache, which maps an exponent to its power.
+func (e *HEEngine) power(x *HEData, k int, cache map[int]*HEData) (*HEData, error) {
+	if k == 1 {
+		return x, nil
+	}
+	if p, ok := cache[k]; ok {
+		return p, nil
+	}
+	hi, err := e.power(x, (k+1)/2, cache)
+	if err != nil {
+		return nil, err
+	}
+	lo, err := e.power(x, k/2, cache)
+	if err != nil {
+		return nil, err
+	}
+	p, err := e.Mult(hi, lo)
+	if err != nil {
+		return nil, fmt.Errorf("x^%d: %w", k, err)
+	}
+	cache[k] = p
+	return p, nil
+}
+
+// RawMoment computes the k-th raw moment E[X^k] of ct, replicated in every slot.
+func (e *HEEngine) RawMoment(ct *HEData, k int) (*HEData, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("invalid moment order: %d", k)
+	}
+	return e.momentOf(ct, k, 1)
+}
+
+// CentralMoment computes the k-th central moment E[(X - μ)^k] of ct, replicated in every slot.
+func (e *HEEngine) CentralMoment(ct *HEData, k int) (*HEData, error) {
+	return e.centralMoment(ct, k, 1)
+}
+
+// StandardizedMoment computes the k-th standardized moment E[(X - μ)^k]/σ^k of ct, with the
+// population standard deviation from s. B bounds the data as in InvSqrtStrategy.InvStd: the
+// population variance must be at most 2B². k = 3 is the skewness and k = 4 the kurtosis (see
+// Skewness and Kurtosis).
+func (e *HEEngine) StandardizedMoment(ct *HEData, k int, B float64, s InvSqrtStrategy) (*HEData, error) {
+	return e.standardizedMoment(ct, k, B, s, 1)
+}
+
+// centralMoment returns factor·E[(X - μ)^k].
+func (e *HEEngine) centralMoment(ct *HEData, k int, factor float64) (*HEData, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("invalid moment order: %d", k)
+	}
+	mean, err := e.Mean(ct)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	centered, err := e.Sub(ct, mean)
+	if err != nil {
+		return nil, fmt.Errorf("center: %w", err)
+	}
+	return e.momentOf(centered, k, factor)
+}
+
+// standardizedMoment returns factor·E[(X - μ)^k]/σ^k.
+func (e *HEEngine) standardizedMoment(ct *HEData, k int, B float64, s InvSqrtStrategy, factor float64) (*HEData, error) {
+	// Step 1: factor·E[(X - μ)^k]
+	numerator, err := e.centralMoment(ct, k, factor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: 1/σ
+	invSigma, err := s.InvStd(e, ct, B)
+	if err != nil {
+		return nil, fmt.Errorf("inverse standard deviation: %w", err)
+	}
+
+	// Step 3: factor·E[(X - μ)^k] × (1/σ)^k
+	return e.standardize(numerator, invSigma, k)
+}
+
+// momentOf returns factor·E[x^k], the factor being folded into the division by n of the mean.
+// With bootstrapping, x is first refreshed so that the power tree and the mean fit in its levels.
+func (e *HEEngine) momentOf(x *HEData, k int, factor float64) (*HEData, error) {
+	var err error
+	if e.IsBTS {
+		if x, err = e.DoBootstrap(x, powerDepth(k)+1); err != nil {
+			return nil, fmt.Errorf("bootstrap (moment input): %w", err)
+		}
+	}
+	xk, err := e.power(x, k, map[int]*HEData{})
+	if err != nil {
+		return nil, err
+	}
+	sum, err := e.Sum(xk)
+	if err != nil {
+		return nil, fmt.Errorf("sum of x^%d: %w", k, err)
+	}
+	moment, err := e.MultConst(sum, factor/float64(xk.Size()))
+	if err != nil {
+		return nil, fmt.Errorf("mean of x^%d: %w", k, err)
+	}
+	return moment, nil
+}
+
+// standardize returns numerator × (1/σ)^k, with invSigma holding 1/σ in a single ciphertext.
+// The power is taken after bootstrapping 1/σ rather than bootstrapping (1/σ)^k, which keeps the
+// bootstrapped value small.
+func (e *HEEngine) standardize(numerator, invSigma *HEData, k int) (*HEData, error) {
+	var err error
+	if e.IsBTS {
+		if invSigma, err = e.DoBootstrap(invSigma, powerDepth(k)+1); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σ): %w", err)
+		}
+		if numerator, err = e.DoBootstrap(numerator, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (numerator): %w", err)
+		}
+	}
+	invSigmaK, err := e.power(invSigma, k, map[int]*HEData{})
+	if err != nil {
+		return nil, fmt.Errorf("inverse standard deviation power: %w", err)
+	}
+	invSigmaK, err = e.extendOneToMulty(invSigmaK, len(numerator.Ciphertexts()), numerator.Size())
+	if err != nil {
+		return nil, fmt.Errorf("extendOneToMulty (1/σ^%d): %w", k, err)
+	}
+	moment, err := e.Mult(numerator, invSigmaK)
+	if err != nil {
+		return nil, fmt.Errorf("final multiply: %w", err)
+	}
+	return moment, nil
+}
+
+// Estimator selects between the population moments, which divide by n, and the bias-adjusted
+// sample estimators reported by default by most statistical packages.
+type Estimator int
+
+const (
+	// Population uses the moments of the data as a whole: the variance m₂ = E[(X - μ)²], the
+	// skewness g₁ = m₃/m₂^(3/2) and the excess kurtosis g₂ = m₄/m₂² - 3.
+	Population Estimator = iota
+	// Sample uses the unbiased variance n/(n-1)·m₂, the adjusted Fisher–Pearson skewness
+	// G₁ = √(n(n-1))/(n-2)·g₁ and the adjusted excess kurtosis
+	// G₂ = (n-1)/((n-2)(n-3))·((n+1)·g₂ + 6).
+	Sample
+)
+
+// VarianceEstimate computes the variance of ct with the estimator est, as the mean of the
+// squared deviations from the encrypted mean. The sample correction n/(n-1) is folded into the
+// division by n.
+func (e *HEEngine) VarianceEstimate(ct *HEData, est Estimator) (*HEData, error) {
+	n := float64(ct.Size())
+	factor := 1.0
+	if est == Sample {
+		if n < 2 {
+			return nil, fmt.Errorf("sample variance needs at least 2 values, got %d", ct.Size())
+		}
+		factor = n / (n - 1)
+	}
+	return e.centralMoment(ct, 2, factor)
+}
+
+// SkewnessEstimate computes the skewness of ct with the estimator est (see Skewness).
+func (e *HEEngine) SkewnessEstimate(ct *HEData, B float64, s InvSqrtStrategy, est Estimator) (*HEData, error) {
+	n := float64(ct.Size())
+	factor := 1.0
+	if est == Sample {
+		if n < 3 {
+			return nil, fmt.Errorf("sample skewness needs at least 3 values, got %d", ct.Size())
+		}
+		factor = math.Sqrt(n*(n-1)) / (n - 2)
+	}
+	return e.standardizedMoment(ct, 3, B, s, factor)
+}
+
+// KurtosisEstimate computes the excess kurtosis of ct with the estimator est (see Kurtosis). The
+// sample estimator is G₂ = a·m₄/m₂² - c with a = (n²-1)/((n-2)(n-3)) and c = 3(n-1)²/((n-2)(n-3)).
+func (e *HEEngine) KurtosisEstimate(ct *HEData, B float64, s InvSqrtStrategy, est Estimator) (*HEData, error) {
+	n := float64(ct.Size())
+	factor, bias := 1.0, 3.0
+	if est == Sample {
+		if n < 4 {
+			return nil, fmt.Errorf("sample kurtosis needs at least 4 values, got %d", ct.Size())
+		}
+		d := (n - 2) * (n - 3)
+		factor, bias = (n*n-1)/d, 3*(n-1)*(n-1)/d
+	}
+	kurtosis, err := e.standardizedMoment(ct, 4, B, s, factor)
+	if err != nil {
+		return nil, err
+	}
+	if kurtosis, err = e.SubConst(kurtosis, bias); err != nil {
+		return nil, fmt.Errorf("subtract bias: %w", err)
+	}
+	return kurtosis, nil
+}
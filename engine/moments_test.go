@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestMoments(t *testing.T) {
+	e := testEngine(t)
+	x := normalData(1, 300, 0.5, 1)
+	ct := encryptTest(t, e, x)
+
+	// The exponents 5 and 6 take the uneven and the balanced branches of the power tree
+	for _, k := range []int{1, 2, 5, 6} {
+		raw, err := e.RawMoment(ct, k)
+		checkScalar(t, fmt.Sprintf("RawMoment(%d)", k), decryptTest(t, e, raw, err), utils.RawMoment(x, k), 1e-6)
+		central, err := e.CentralMoment(ct, k)
+		checkScalar(t, fmt.Sprintf("CentralMoment(%d)", k), decryptTest(t, e, central, err), utils.CentralMoment(x, k), 1e-6)
+	}
+	std, err := e.StandardizedMoment(ct, 5, 5, PPStat{})
+	checkScalar(t, "StandardizedMoment(5)", decryptTest(t, e, std, err), utils.StandardizedMoment(x, 5), 1e-4)
+
+	skew, err := e.Skewness(ct, 5, PPStat{})
+	_, _, wantSkew := utils.Skewness(x)
+	checkScalar(t, "Skewness", decryptTest(t, e, skew, err), wantSkew, 1e-4)
+	kurt, err := e.Kurtosis(ct, 5, PPStat{})
+	_, _, wantKurt := utils.Kurtosis(x)
+	checkScalar(t, "Kurtosis", decryptTest(t, e, kurt, err), wantKurt, 1e-4)
+}
+
+func TestEstimators(t *testing.T) {
+	e := testEngine(t)
+	// A small sample, where the sample corrections are large
+	x := normalData(2, 20, 1, 2)
+	ct := encryptTest(t, e, x)
+
+	variance, err := e.VarianceEstimate(ct, Population)
+	checkScalar(t, "population variance", decryptTest(t, e, variance, err), utils.Variance(x), 1e-6)
+	variance, err = e.VarianceEstimate(ct, Sample)
+	checkScalar(t, "sample variance", decryptTest(t, e, variance, err), utils.SampleVariance(x), 1e-6)
+
+	skew, err := e.SkewnessEstimate(ct, 5, PPStat{}, Sample)
+	checkScalar(t, "sample skewness", decryptTest(t, e, skew, err), utils.SampleSkewness(x), 1e-4)
+	kurt, err := e.KurtosisEstimate(ct, 5, PPStat{}, Sample)
+	checkScalar(t, "sample kurtosis", decryptTest(t, e, kurt, err), utils.SampleKurtosis(x), 1e-4)
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// normalizePrecision is the relative error targeted by the inverse of the spread in MinMaxNorm
+// and RobustNorm.
+const normalizePrecision = 1e-6
+
+// Normalizer rescales an encrypted column, so that a pipeline can switch between ZScoreNorm,
+// MinMaxNorm and RobustNorm without changing the rest of its code.
+type Normalizer interface {
+	Normalize(e *HEEngine, ct *HEData) (*HEData, error)
+}
+
+// ZScore is the Normalizer of ZScoreNorm.
+type ZScore struct {
+	B        float64
+	Strategy InvSqrtStrategy
+}
+
+// Normalize implements Normalizer.
+func (z ZScore) Normalize(e *HEEngine, ct *HEData) (*HEData, error) {
+	return e.ZScoreNorm(ct, z.B, z.Strategy)
+}
+
+// MinMax is the Normalizer of MinMaxNorm.
+type MinMax struct {
+	Bound    float64
+	MinRange float64
+	Iter     int
+}
+
+// Normalize implements Normalizer.
+func (m MinMax) Normalize(e *HEEngine, ct *HEData) (*HEData, error) {
+	return e.MinMaxNorm(ct, m.Bound, m.MinRange, m.Iter)
+}
+
+// Robust is the Normalizer of RobustNorm.
+type Robust struct {
+	Bound  float64
+	MinIQR float64
+	Iter   int
+	Steps  int
+}
+
+// Normalize implements Normalizer.
+func (r Robust) Normalize(e *HEEngine, ct *HEData) (*HEData, error) {
+	return e.RobustNorm(ct, r.Bound, r.MinIQR, r.Iter, r.Steps)
+}
+
+// MinMaxNorm scales the values of ct to [0, 1] as (x - min)/(max - min), with Min and Max
+// evaluated with iter sign compositions for |x| ≤ bound. The range must be at least minRange,
+// which sets the interval [minRange, 2·bound] of its encrypted inverse.
+func (e *HEEngine) MinMaxNorm(ct *HEData, bound, minRange float64, iter int) (*HEData, error) {
+	// Step 1: min and max
+	lo, err := e.Min(ct, bound, iter)
+	if err != nil {
+		return nil, fmt.Errorf("min: %w", err)
+	}
+	hi, err := e.Max(ct, bound, iter)
+	if err != nil {
+		return nil, fmt.Errorf("max: %w", err)
+	}
+
+	// Step 2: (x - min)/(max - min)
+	return e.scaleBySpread(ct, lo, hi, lo, minRange, 2*bound)
+}
+
+// RobustNorm scales the values of ct as (x - median)/(Q3 - Q1), with the nearest-rank quartiles
+// of Quantile evaluated with iter Step compositions and steps bisection rounds for |x| ≤ bound.
+// The interquartile range must be at least minIQR, which sets the interval [minIQR, 2·bound] of
+// its encrypted inverse.
+func (e *HEEngine) RobustNorm(ct *HEData, bound, minIQR float64, iter, steps int) (*HEData, error) {
+	// Step 1: Quartiles
+	quartiles := make([]*HEData, 3)
+	for i, q := range []float64{0.25, 0.5, 0.75} {
+		var err error
+		if quartiles[i], err = e.Quantile(ct, q, bound, iter, steps); err != nil {
+			return nil, fmt.Errorf("quantile %g: %w", q, err)
+		}
+	}
+
+	// Step 2: (x - median)/(Q3 - Q1)
+	return e.scaleBySpread(ct, quartiles[1], quartiles[2], quartiles[0], minIQR, 2*bound)
+}
+
+// scaleBySpread returns (x - center)/(upper - lower), where upper - lower ∈ [minSpread, maxSpread].
+// As in ChiSquare, the spread is inverted as spread/minSpread ∈ [1, maxSpread/minSpread], so that
+// the inverse stays in (0, 1] and can be bootstrapped accurately.
+func (e *HEEngine) scaleBySpread(ct, center, upper, lower *HEData, minSpread, maxSpread float64) (*HEData, error) {
+	if !(minSpread > 0 && maxSpread > minSpread) {
+		return nil, fmt.Errorf("invalid spread interval [%g, %g]", minSpread, maxSpread)
+	}
+	hi := maxSpread / minSpread
+
+	// Step 1: minSpread/spread
+	spread, err := e.Sub(upper, lower)
+	if err != nil {
+		return nil, fmt.Errorf("spread: %w", err)
+	}
+	if e.IsBTS {
+		if spread, err = e.DoBootstrap(spread, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (spread): %w", err)
+		}
+	}
+	if spread, err = e.MultConst(spread, 1/minSpread); err != nil {
+		return nil, fmt.Errorf("scale spread: %w", err)
+	}
+	inv, err := e.Inverse(spread, 1, hi, normalizePrecision)
+	if err != nil {
+		return nil, fmt.Errorf("inverse of spread: %w", err)
+	}
+	if e.IsBTS {
+		if inv, err = e.DoBootstrap(inv, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (inverse of spread): %w", err)
+		}
+		if center, err = e.DoBootstrap(center, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (center): %w", err)
+		}
+	}
+
+	// Step 2: (x - center)/minSpread × minSpread/spread
+	num, size := len(ct.Ciphertexts()), ct.Size()
+	if center, err = e.extendOneToMulty(center, num, size); err != nil {
+		return nil, err
+	}
+	if inv, err = e.extendOneToMulty(inv, num, size); err != nil {
+		return nil, err
+	}
+	centered, err := e.Sub(ct, center)
+	if err != nil {
+		return nil, fmt.Errorf("center input: %w", err)
+	}
+	if centered, err = e.MultConst(centered, 1/minSpread); err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+	return e.Mult(centered, inv)
+}
@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// Max approximates the largest value of ct, assuming |x| ≤ bound, replicated in every slot of a
+// single ciphertext.
+//
+// The ciphertexts and then the slots are reduced pairwise as max(a, b) = (a + b + |a - b|)/2,
+// with |d| = d·Sign(d/(2·bound)) and iter sign compositions (see Sign): 1 + log2(Slots) rounds
+// for a single ciphertext. A pair closer than 2·bound·2^-(iter-1) is merged into a value between
+// the two, so the error stays below that gap in every round.
+func (e *HEEngine) Max(ct *HEData, bound float64, iter int) (*HEData, error) {
+	return e.extremum(ct, bound, iter, false)
+}
+
+// Min approximates the smallest value of ct, assuming |x| ≤ bound, as in Max.
+func (e *HEEngine) Min(ct *HEData, bound float64, iter int) (*HEData, error) {
+	return e.extremum(ct, bound, iter, true)
+}
+
+func (e *HEEngine) extremum(ct *HEData, bound float64, iter int, smallest bool) (*HEData, error) {
+	if !(bound > 0) {
+		return nil, fmt.Errorf("invalid bound: %g", bound)
+	}
+	slots := e.params.MaxSlots()
+
+	// Step 1: Fill the unused slots with the neutral value -bound (max) or bound (min)
+	neutral := bound
+	if !smallest {
+		neutral = -bound
+	}
+	x, err := e.SubConst(ct, neutral)
+	if err != nil {
+		return nil, fmt.Errorf("shift input: %w", err)
+	}
+	mask := make([]float64, ct.Size())
+	for i := range mask {
+		mask[i] = 1
+	}
+	if x, err = e.MultPlain(x, mask); err != nil {
+		return nil, fmt.Errorf("mask input: %w", err)
+	}
+	if x, err = e.AddConst(x, neutral); err != nil {
+		return nil, fmt.Errorf("fill unused slots: %w", err)
+	}
+
+	// Step 2: Reduce the ciphertexts pairwise
+	ctxts := x.Ciphertexts()
+	for len(ctxts) > 1 {
+		var next []*rlwe.Ciphertext
+		for i := 0; i+1 < len(ctxts); i += 2 {
+			a := NewHEData([]*rlwe.Ciphertext{ctxts[i]}, slots, ctxts[i].Level(), ct.Scale())
+			b := NewHEData([]*rlwe.Ciphertext{ctxts[i+1]}, slots, ctxts[i+1].Level(), ct.Scale())
+			m, err := e.pairExtremum(a, b, bound, iter, smallest)
+			if err != nil {
+				return nil, fmt.Errorf("ciphertexts %d and %d: %w", i, i+1, err)
+			}
+			next = append(next, m.Ciphertexts()[0])
+		}
+		if len(ctxts)%2 == 1 {
+			next = append(next, ctxts[len(ctxts)-1])
+		}
+		ctxts = next
+	}
+
+	// Step 3: Reduce the slots by halving rotations
+	acc := NewHEData(ctxts[:1], slots, ctxts[0].Level(), ct.Scale())
+	for shift := slots / 2; shift >= 1; shift /= 2 {
+		rotated, err := e.Rotate(acc, shift)
+		if err != nil {
+			return nil, fmt.Errorf("rotate by %d: %w", shift, err)
+		}
+		if acc, err = e.pairExtremum(acc, rotated, bound, iter, smallest); err != nil {
+			return nil, fmt.Errorf("round %d: %w", shift, err)
+		}
+	}
+
+	return NewHEData(acc.Ciphertexts(), max(1, min(ct.Size(), slots)), acc.Level(), ct.Scale()), nil
+}
+
+// pairExtremum returns the slot-wise max(a, b) = (a + b + |a - b|)/2, or min(a, b) with the sign
+// of |a - b| flipped. Both inputs must be full ciphertexts.
+func (e *HEEngine) pairExtremum(a, b *HEData, bound float64, iter int, smallest bool) (*HEData, error) {
+	var err error
+	if e.IsBTS {
+		if a, err = e.DoBootstrap(a, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (first operand): %w", err)
+		}
+		if b, err = e.DoBootstrap(b, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (second operand): %w", err)
+		}
+	}
+	diff, err := e.Sub(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("difference: %w", err)
+	}
+	scaled, err := e.MultConst(diff, 1/(2*bound))
+	if err != nil {
+		return nil, fmt.Errorf("scale difference: %w", err)
+	}
+	sign, err := e.Sign(scaled, iter)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	if e.IsBTS {
+		if sign, err = e.DoBootstrap(sign, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (sign): %w", err)
+		}
+	}
+	abs, err := e.Mult(diff, sign)
+	if err != nil {
+		return nil, fmt.Errorf("|a - b|: %w", err)
+	}
+	sum, err := e.Add(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("a + b: %w", err)
+	}
+	if smallest {
+		sum, err = e.Sub(sum, abs)
+	} else {
+		sum, err = e.Add(sum, abs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("a + b ± |a - b|: %w", err)
+	}
+	return e.MultConst(sum, 0.5)
+}
+
+// Quantile approximates the nearest-rank q-quantile x₍ₖ₎, k = round(q·(n-1)) + 1, of the values
+// in ct, assuming |x| ≤ bound, replicated in every slot of a single ciphertext.
+//
+// It bisects [-bound, bound] for steps rounds on the encrypted threshold t, moving t by
+// ±bound·2^-r towards the side where the count C(t) = Σᵢ Step((t - xᵢ)/(2·bound)) crosses
+// k - 1/2. The counts of two thresholds in different gaps of the data differ by at least 1, so
+// the direction Sign((k - 1/2 - C(t))/n) uses enough compositions to resolve 1/(2n). iter
+// compositions of Step resolve a threshold from the values at distance 2·bound·2^-(iter-1) or
+// more. The result is within bound·2^-steps of x₍ₖ₎, plus at most the gap around x₍ₖ₎ when the
+// threshold lands inside the Step resolution of a value.
+func (e *HEEngine) Quantile(ct *HEData, q, bound float64, iter, steps int) (*HEData, error) {
+	if !(q >= 0 && q <= 1) {
+		return nil, fmt.Errorf("invalid quantile: %g", q)
+	}
+	if !(bound > 0) {
+		return nil, fmt.Errorf("invalid bound: %g", bound)
+	}
+	if steps < 1 {
+		return nil, fmt.Errorf("invalid bisection step count: %d", steps)
+	}
+	n := ct.Size()
+	num := len(ct.Ciphertexts())
+	target := math.Round(q*float64(n-1)) + 0.5
+	// Step(0) = 1/2 in each unused slot
+	padding := float64(num*e.params.MaxSlots()-n) / 2
+	directionIter := bits.Len(uint(2*n)) + 1
+
+	// Step 1: xᵢ/(2·bound), the first threshold being t = 0
+	xs, err := e.MultConst(ct, 1/(2*bound))
+	if err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+
+	var t *HEData
+	for r := 1; r <= steps; r++ {
+		// Step 2: C(t) = Σᵢ Step((t - xᵢ)/(2·bound))
+		var diff *HEData
+		if t == nil {
+			diff, err = e.MultConst(xs, -1)
+		} else {
+			// MultConst leaves the unused slots of the extended threshold at zero
+			var ts *HEData
+			if ts, err = e.extendOneToMulty(t, num, n); err != nil {
+				return nil, err
+			}
+			if ts, err = e.MultConst(ts, 1/(2*bound)); err != nil {
+				return nil, fmt.Errorf("scale threshold (round %d): %w", r, err)
+			}
+			diff, err = e.Sub(ts, xs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("difference (round %d): %w", r, err)
+		}
+		step, err := e.Step(diff, iter)
+		if err != nil {
+			return nil, fmt.Errorf("step (round %d): %w", r, err)
+		}
+		if e.IsBTS {
+			if step, err = e.DoBootstrap(step, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (step, round %d): %w", r, err)
+			}
+		}
+		count, err := e.Sum(step)
+		if err != nil {
+			return nil, fmt.Errorf("count (round %d): %w", r, err)
+		}
+		if count, err = e.selectOneCtxt(count); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (count, round %d): %w", r, err)
+		}
+
+		// Step 3: t ← t ± bound·2^-r, towards C(t) = k - 1/2
+		if count, err = e.SubConst(count, padding+target); err != nil {
+			return nil, fmt.Errorf("center count (round %d): %w", r, err)
+		}
+		if count, err = e.MultConst(count, -1/float64(n)); err != nil {
+			return nil, fmt.Errorf("scale count (round %d): %w", r, err)
+		}
+		dir, err := e.Sign(count, directionIter)
+		if err != nil {
+			return nil, fmt.Errorf("direction (round %d): %w", r, err)
+		}
+		if e.IsBTS {
+			if dir, err = e.DoBootstrap(dir, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (direction, round %d): %w", r, err)
+			}
+		}
+		move, err := e.MultConst(dir, bound*math.Pow(2, -float64(r)))
+		if err != nil {
+			return nil, fmt.Errorf("scale direction (round %d): %w", r, err)
+		}
+		if t == nil {
+			t = move
+		} else if t, err = e.Add(t, move); err != nil {
+			return nil, fmt.Errorf("update threshold (round %d): %w", r, err)
+		}
+		if e.IsBTS {
+			if t, err = e.DoBootstrap(t, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (threshold, round %d): %w", r, err)
+			}
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestMinMaxNorm(t *testing.T) {
+	e := testDeepEngine(t)
+	x := normalData(1, 30, 1, 3)
+	ct := encryptTest(t, e, x)
+
+	lo, err := e.Min(ct, 10, 8)
+	checkScalar(t, "Min", decryptTest(t, e, lo, err), slices.Min(x), 1e-4)
+	hi, err := e.Max(ct, 10, 8)
+	checkScalar(t, "Max", decryptTest(t, e, hi, err), slices.Max(x), 1e-4)
+
+	norm, err := e.MinMaxNorm(ct, 10, 1, 8)
+	checkClose(t, "MinMaxNorm", decryptTest(t, e, norm, err), utils.MinMaxNorm(x), 1e-4)
+}
+
+func TestRobustNorm(t *testing.T) {
+	e := testDeepEngine(t)
+	x := normalData(2, 30, 1, 3)
+	ct := encryptTest(t, e, x)
+
+	// 6 bisection rounds locate the quartiles within 10·2^-6 ≈ 0.16
+	median, err := e.Quantile(ct, 0.5, 10, 6, 6)
+	checkScalar(t, "median", decryptTest(t, e, median, err), utils.Quantile(x, 0.5), 0.1)
+
+	norm, err := e.RobustNorm(ct, 10, 0.5, 6, 6)
+	checkClose(t, "RobustNorm", decryptTest(t, e, norm, err), utils.RobustNorm(x), 0.1)
+}
@@ -0,0 +1,228 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// outlierPrecision is the relative error targeted by the inverse of the inlier proportion.
+const outlierPrecision = 1e-6
+
+// OutlierResult holds an encrypted 0/1 outlier indicator for every value, and the outlier count,
+// mean and population variance of the remaining values, each replicated in every slot of a
+// single ciphertext.
+type OutlierResult struct {
+	Mask     *HEData
+	Count    *HEData
+	Mean     *HEData
+	Variance *HEData
+}
+
+// OutlierMask returns an encrypted indicator of |z| > k for the population z-scores of ct: close
+// to 1 for an outlier and to 0 otherwise, and 0 in the unused slots.
+//
+// The z-scores are computed divided by zBound, which must bound every |z|, so that the
+// comparison Step(u² - (k/zBound)²) with u = z/zBound is evaluated on [-1, 1]. A z-score at
+// distance δ from k is resolved when 2kδ/zBound² exceeds 2^-(iter-1) (see Sign). B bounds the
+// data as in InvSqrtStrategy.InvStd: the population variance must be at most 2B².
+func (e *HEEngine) OutlierMask(ct *HEData, k, zBound float64, iter int, B float64, s InvSqrtStrategy) (*HEData, error) {
+	if !(k > 0 && zBound > k) {
+		return nil, fmt.Errorf("invalid outlier threshold %g for z-score bound %g", k, zBound)
+	}
+
+	// Step 1: u = (x - μ)/(σ·zBound)
+	mean, err := e.Mean(ct)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	centered, err := e.Sub(ct, mean)
+	if err != nil {
+		return nil, fmt.Errorf("center: %w", err)
+	}
+	invSigma, err := s.InvStd(e, ct, B)
+	if err != nil {
+		return nil, fmt.Errorf("inverse standard deviation: %w", err)
+	}
+	if e.IsBTS {
+		if invSigma, err = e.DoBootstrap(invSigma, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σ): %w", err)
+		}
+	}
+	if invSigma, err = e.MultConst(invSigma, 1/zBound); err != nil {
+		return nil, fmt.Errorf("scale 1/σ: %w", err)
+	}
+	invSigma, err = e.extendOneToMulty(invSigma, len(centered.Ciphertexts()), centered.Size())
+	if err != nil {
+		return nil, err
+	}
+	u, err := e.Mult(centered, invSigma)
+	if err != nil {
+		return nil, fmt.Errorf("scaled z-scores: %w", err)
+	}
+
+	// Step 2: Step(u² - (k/zBound)²)
+	if e.IsBTS {
+		if u, err = e.DoBootstrap(u, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (scaled z-scores): %w", err)
+		}
+	}
+	u2, err := e.Mult(u, u)
+	if err != nil {
+		return nil, fmt.Errorf("squared z-scores: %w", err)
+	}
+	threshold := k / zBound
+	if u2, err = e.SubConst(u2, threshold*threshold); err != nil {
+		return nil, fmt.Errorf("subtract threshold: %w", err)
+	}
+	step, err := e.Step(u2, iter)
+	if err != nil {
+		return nil, fmt.Errorf("step: %w", err)
+	}
+
+	// Step 3: Zero the unused slots
+	if e.IsBTS {
+		if step, err = e.DoBootstrap(step, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (step): %w", err)
+		}
+	}
+	ones := make([]float64, ct.Size())
+	for i := range ones {
+		ones[i] = 1
+	}
+	return e.MultPlain(step, ones)
+}
+
+// OutlierStats computes the outlier mask of OutlierMask, with the number of outliers and the
+// mean and population variance of the inliers.
+//
+// By Chebyshev's inequality at most n/k² values have |z| ≥ k, so for k > 1 the inlier proportion
+// p lies in [1 - 1/k², 1], a narrow interval on which Inverse is cheap and accurate. The inlier
+// moments are means over all the values weighted by the inlier indicator and divided by p. The
+// values are centered first, as in ANOVA, so that the weighted moments are small.
+func (e *HEEngine) OutlierStats(ct *HEData, k, zBound float64, iter int, B float64, s InvSqrtStrategy) (*OutlierResult, error) {
+	if !(k > 1) {
+		return nil, fmt.Errorf("outlier threshold must be greater than 1, got %g", k)
+	}
+
+	// Step 1: Outlier mask and count
+	mask, err := e.OutlierMask(ct, k, zBound, iter, B, s)
+	if err != nil {
+		return nil, err
+	}
+	count, err := e.Sum(mask)
+	if err != nil {
+		return nil, fmt.Errorf("outlier count: %w", err)
+	}
+	if count, err = e.selectOneCtxt(count); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (outlier count): %w", err)
+	}
+
+	// Step 2: Inlier indicator w = 1 - mask, zero in the unused slots, and 1/p
+	inliers, err := e.SubConst(mask, 1)
+	if err != nil {
+		return nil, fmt.Errorf("inlier indicator: %w", err)
+	}
+	if inliers, err = e.MultConst(inliers, -1); err != nil {
+		return nil, fmt.Errorf("inlier indicator: %w", err)
+	}
+	prop, err := e.Mean(inliers)
+	if err != nil {
+		return nil, fmt.Errorf("inlier proportion: %w", err)
+	}
+	if prop, err = e.selectOneCtxt(prop); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (inlier proportion): %w", err)
+	}
+	invProp, err := e.Inverse(prop, 1-1/(k*k), 1, outlierPrecision)
+	if err != nil {
+		return nil, fmt.Errorf("inverse of inlier proportion: %w", err)
+	}
+	if e.IsBTS {
+		if invProp, err = e.DoBootstrap(invProp, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (inverse of inlier proportion): %w", err)
+		}
+		if inliers, err = e.DoBootstrap(inliers, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (inlier indicator): %w", err)
+		}
+	}
+
+	// Step 3: Inlier mean of the centered values, E[w·x]/p
+	mean, err := e.Mean(ct)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	centered, err := e.Sub(ct, mean)
+	if err != nil {
+		return nil, fmt.Errorf("center: %w", err)
+	}
+	if e.IsBTS {
+		if centered, err = e.DoBootstrap(centered, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (centered values): %w", err)
+		}
+	}
+	weighted, err := e.Mult(centered, inliers)
+	if err != nil {
+		return nil, fmt.Errorf("weighted values: %w", err)
+	}
+	inlierMean, err := e.Mean(weighted)
+	if err != nil {
+		return nil, fmt.Errorf("weighted mean: %w", err)
+	}
+	if inlierMean, err = e.selectOneCtxt(inlierMean); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (weighted mean): %w", err)
+	}
+	if e.IsBTS {
+		if inlierMean, err = e.DoBootstrap(inlierMean, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (weighted mean): %w", err)
+		}
+	}
+	if inlierMean, err = e.Mult(inlierMean, invProp); err != nil {
+		return nil, fmt.Errorf("inlier mean: %w", err)
+	}
+
+	// Step 4: Inlier variance E[w·(x - μ_in)²]/p
+	num, size := len(centered.Ciphertexts()), centered.Size()
+	extended, err := e.extendOneToMulty(inlierMean, num, size)
+	if err != nil {
+		return nil, err
+	}
+	dev, err := e.Sub(centered, extended)
+	if err != nil {
+		return nil, fmt.Errorf("inlier deviations: %w", err)
+	}
+	if e.IsBTS {
+		if dev, err = e.DoBootstrap(dev, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (inlier deviations): %w", err)
+		}
+	}
+	sq, err := e.Mult(dev, dev)
+	if err != nil {
+		return nil, fmt.Errorf("squared deviations: %w", err)
+	}
+	if sq, err = e.Mult(sq, inliers); err != nil {
+		return nil, fmt.Errorf("weighted squared deviations: %w", err)
+	}
+	variance, err := e.Mean(sq)
+	if err != nil {
+		return nil, fmt.Errorf("weighted variance: %w", err)
+	}
+	if variance, err = e.selectOneCtxt(variance); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (weighted variance): %w", err)
+	}
+	if e.IsBTS {
+		if variance, err = e.DoBootstrap(variance, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (weighted variance): %w", err)
+		}
+	}
+	if variance, err = e.Mult(variance, invProp); err != nil {
+		return nil, fmt.Errorf("inlier variance: %w", err)
+	}
+
+	// Step 5: Shift the inlier mean back
+	if mean, err = e.selectOneCtxt(mean); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (mean): %w", err)
+	}
+	if inlierMean, err = e.Add(inlierMean, mean); err != nil {
+		return nil, fmt.Errorf("inlier mean: %w", err)
+	}
+
+	return &OutlierResult{Mask: mask, Count: count, Mean: inlierMean, Variance: variance}, nil
+}
@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// outlierData returns n values with a few far outliers, every z-score staying at least 0.5 away
+// from the threshold 3.
+func outlierData(seed int64, n int) []float64 {
+	x := normalData(seed, n, 2, 1)
+	for i := range x {
+		x[i] = 2 + math.Max(-2, math.Min(2, x[i]-2))
+		if i%50 == 0 {
+			x[i] = 12
+		}
+	}
+	return x
+}
+
+func TestOutlierStats(t *testing.T) {
+	e := testEngine(t)
+	x := outlierData(1, 300)
+	wantCount, wantMean, wantVariance := utils.OutlierStats(x, 3)
+	wantMask := make([]float64, len(x))
+	for i, z := range utils.ZScoreNorm(x) {
+		if math.Abs(z) > 3 {
+			wantMask[i] = 1
+		}
+	}
+
+	res, err := e.OutlierStats(encryptTest(t, e, x), 3, 8, 10, 5, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "mask", decryptTest(t, e, res.Mask, nil), wantMask, 1e-3)
+	checkScalar(t, "count", decryptTest(t, e, res.Count, nil), float64(wantCount), 1e-3)
+	checkScalar(t, "inlier mean", decryptTest(t, e, res.Mean, nil), wantMean, 1e-3)
+	checkScalar(t, "inlier variance", decryptTest(t, e, res.Variance, nil), wantVariance, 1e-3)
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// PiecewiseMin is the lower end of the normalized inputs x/(2B) covered by the piecewise inverse
+// square root of HEDAP and of the optimizer, below the 0.001/100 ratio of experiment 1.
+const PiecewiseMin = 1.0 / 131072
+
+// Piecewise approximates 1/√x over a wide dynamic range with one low-degree polynomial per
+// sub-interval instead of a single polynomial over (0, 2B]. The normalized input y = x/(2B) is
+// covered by Pieces geometric sub-intervals of [Min, 1], on each of which 1/√x is interpolated
+// with the given degree. The blend of the pieces is refined with Iter Newton iterations.
+type Piecewise struct {
+	Min    float64
+	Pieces int
+	Degree int
+	Iter   int
+}
+
+// InvSqrt evaluates the piecewise guess on x and refines it on x/2.
+func (p Piecewise) InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error) {
+	// Step 1: Piecewise guess for 1/√x
+	init, err := e.PiecewiseInvSqrtInit(x, B, p)
+	if err != nil {
+		return nil, err
+	}
+	if e.IsBTS {
+		if init, err = e.DoBootstrap(init, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (piecewise guess): %w", err)
+		}
+	}
+
+	// Step 2: Newton refinement on x/2
+	half, err := scaleFill(e, x, 0.5, B/2)
+	if err != nil {
+		return nil, fmt.Errorf("halve input: %w", err)
+	}
+	invSqrt, err := e.HENewtonInv(half, init, B, p.Iter, 2)
+	if err != nil {
+		return nil, fmt.Errorf("HENewtonInv: %w", err)
+	}
+	return invSqrt, nil
+}
+
+// InvStd computes 1/√Var[X] with InvSqrt.
+func (p Piecewise) InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error) {
+	return invStdOf(e, p, ct, B)
+}
+
+// PiecewiseBounds returns the geometric bounds Min = a_0 < a_1 < ... < a_Pieces = 1 of the
+// sub-intervals of p.
+func PiecewiseBounds(p Piecewise) ([]float64, error) {
+	if !(p.Min > 0 && p.Min < 1) {
+		return nil, fmt.Errorf("invalid piecewise domain [%g, 1]", p.Min)
+	}
+	if p.Pieces < 1 {
+		return nil, fmt.Errorf("invalid piece count: %d", p.Pieces)
+	}
+	bounds := make([]float64, p.Pieces+1)
+	for j := range bounds {
+		bounds[j] = math.Pow(p.Min, 1-float64(j)/float64(p.Pieces))
+	}
+	bounds[p.Pieces] = 1
+	return bounds, nil
+}
+
+// PiecewiseInvSqrtInit approximates 1/√x for 2B·p.Min ≤ x ≤ 2B without refinement.
+//
+// With y = x/(2B) and the bounds a_j of PiecewiseBounds, every inner bound gets an indicator
+// t_j = Step(y - a_j). The input of piece j is clamped to its sub-interval as
+// z_j = a_j + t_j·(y - a_j) - t_{j+1}·(y - a_{j+1}), so that no polynomial is evaluated far
+// outside its interval, and the pieces are blended as q_0 + Σ t_j·(q_j - q_{j-1}) with
+// q_j = 1/√(2B·z_j). The indicators telescope, so the weights always sum to 1; close to a bound,
+// where the indicator is undecided, both neighboring pieces are evaluated close to the bound and
+// agree. Step runs enough compositions to decide |y - a_1| ≥ a_1/2 for the smallest inner bound.
+func (e *HEEngine) PiecewiseInvSqrtInit(x *HEData, B float64, p Piecewise) (*HEData, error) {
+	bounds, err := PiecewiseBounds(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: y = x/(2B), 1/2 in the unused slots
+	y, err := scaleFill(e, x, 1/(2*B), 0.5)
+	if err != nil {
+		return nil, fmt.Errorf("normalize input: %w", err)
+	}
+	if p.Pieces == 1 {
+		return e.piecewiseEval(y, bounds[0], bounds[1], B, p.Degree)
+	}
+
+	// Step 2: Indicators t_j and ramps t_j·(y - a_j) of the inner bounds
+	signIter := int(math.Ceil(-math.Log2(bounds[1]))) + 2
+	ts := make([]*HEData, p.Pieces)
+	ramps := make([]*HEData, p.Pieces)
+	for j := 1; j < p.Pieces; j++ {
+		shifted, err := e.SubConst(y, bounds[j])
+		if err != nil {
+			return nil, fmt.Errorf("threshold (bound %d): %w", j, err)
+		}
+		if ts[j], err = e.Step(shifted, signIter); err != nil {
+			return nil, fmt.Errorf("indicator (bound %d): %w", j, err)
+		}
+		if e.IsBTS {
+			if ts[j], err = e.DoBootstrap(ts[j], 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (indicator %d): %w", j, err)
+			}
+			if shifted, err = e.DoBootstrap(shifted, 1); err != nil {
+				return nil, fmt.Errorf("bootstrap (threshold %d): %w", j, err)
+			}
+		}
+		if ramps[j], err = e.Mult(ts[j], shifted); err != nil {
+			return nil, fmt.Errorf("ramp (bound %d): %w", j, err)
+		}
+	}
+
+	// Step 3: q_j on the clamped input z_j, blended with the indicators
+	var result, prev *HEData
+	for j := range p.Pieces {
+		var z *HEData
+		switch j {
+		case 0:
+			z, err = e.Sub(y, ramps[1])
+		case p.Pieces - 1:
+			z, err = e.AddConst(ramps[j], bounds[j])
+		default:
+			if z, err = e.Sub(ramps[j], ramps[j+1]); err == nil {
+				z, err = e.AddConst(z, bounds[j])
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("clamp input (piece %d): %w", j, err)
+		}
+		q, err := e.piecewiseEval(z, bounds[j], bounds[j+1], B, p.Degree)
+		if err != nil {
+			return nil, fmt.Errorf("piece %d: %w", j, err)
+		}
+		if j == 0 {
+			result, prev = q, q
+			continue
+		}
+
+		diff, err := e.Sub(q, prev)
+		if err != nil {
+			return nil, fmt.Errorf("difference (piece %d): %w", j, err)
+		}
+		if e.IsBTS {
+			if diff, err = e.DoBootstrap(diff, 1); err != nil {
+				return nil, fmt.Errorf("bootstrap (difference %d): %w", j, err)
+			}
+		}
+		if diff, err = e.Mult(ts[j], diff); err != nil {
+			return nil, fmt.Errorf("weight (piece %d): %w", j, err)
+		}
+		if result, err = e.Add(result, diff); err != nil {
+			return nil, fmt.Errorf("blend (piece %d): %w", j, err)
+		}
+		prev = q
+	}
+	return result, nil
+}
+
+// piecewiseEval evaluates the interpolant of 1/√(2B·z) on [a, b] of the given degree, from the
+// engine's polynomial cache.
+func (e *HEEngine) piecewiseEval(z *HEData, a, b, B float64, degree int) (*HEData, error) {
+	F := func(u float64) float64 {
+		return 1 / math.Sqrt(2*B*u)
+	}
+	key := PolyKey{Func: "invsqrt-piece", A: a, B: b, Nodes: degree + 1, Bound: B}
+	poly, maxErr, err := e.Polys.PolyError(key, func() (bignum.Polynomial, float64, error) {
+		f, err := NewApproxFunction(F, a, b, degree)
+		if err != nil {
+			return bignum.Polynomial{}, 0, err
+		}
+		return f.poly, f.Error, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.EvalApprox(z, &ApproxFunction{F: F, A: a, B: b, Degree: degree, Error: maxErr, poly: poly})
+}
@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+func TestPiecewise(t *testing.T) {
+	e := testEngine(t)
+	p := Piecewise{Min: 1.0 / 1024, Pieces: 3, Degree: 15, Iter: 6}
+	bounds, err := PiecewiseBounds(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bounds) != p.Pieces+1 || bounds[0] != p.Min || bounds[p.Pieces] != 1 {
+		t.Errorf("bounds %v of %d pieces on [%g, 1]", bounds, p.Pieces, p.Min)
+	}
+
+	// Geometric inputs over the three decades of [2B·Min, 2B] for B = 10
+	x := make([]float64, 60)
+	for i := range x {
+		x[i] = 20 * math.Pow(p.Min, 1-float64(i)/float64(len(x)-1))
+	}
+	checkStrategy(t, e, "Piecewise", p, x, normalData(1, 300, 2, 0.5), 10, 1e-4)
+
+	// The piece interpolants are in the cache for the next evaluation
+	for j := range p.Pieces {
+		key := PolyKey{Func: "invsqrt-piece", A: bounds[j], B: bounds[j+1], Nodes: p.Degree + 1, Bound: 10}
+		if _, err := e.Polys.Poly(key, func() (bignum.Polynomial, error) {
+			return bignum.Polynomial{}, fmt.Errorf("not cached")
+		}); err != nil {
+			t.Errorf("piece %d: %v", j, err)
+		}
+	}
+}
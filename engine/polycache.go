@@ -0,0 +1,218 @@
+package engine
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// PolyKey identifies a polynomial approximation in a PolyCache.
+type PolyKey struct {
+	Func  string  `json:"func"`  // function id, e.g. "invsqrt"
+	A     float64 `json:"a"`     // approximation interval [A, B]
+	B     float64 `json:"b"`     //
+	Nodes int     `json:"nodes"` // the degree plus one, i.e. the nodes of a Chebyshev interpolant
+	Bound float64 `json:"bound"` // data bound B of the function, 0 when unused
+	Mode  int     `json:"mode"`  // function variant, 0 when unused
+}
+
+// PolyCache memoizes polynomial approximations, whose generation (Chebyshev interpolation with
+// 128-bit arithmetic, Remez exchange) dominates the cost of a statistic at high degree. A cache
+// is safe for concurrent use, and can be saved to disk and loaded back, e.g. before constructing
+// an engine with NewHEEngineWithPolys.
+type PolyCache struct {
+	mu    sync.RWMutex
+	polys map[PolyKey]cachedPoly
+}
+
+// cachedPoly is a cached polynomial with its maximum error, 0 when unknown.
+type cachedPoly struct {
+	poly   bignum.Polynomial
+	maxErr float64
+}
+
+// NewPolyCache returns an empty cache.
+func NewPolyCache() *PolyCache {
+	return &PolyCache{polys: map[PolyKey]cachedPoly{}}
+}
+
+// Len returns the number of cached polynomials.
+func (c *PolyCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.polys)
+}
+
+// Chebyshev returns the interpolant of f on [key.A, key.B] with key.Nodes nodes, generating it on
+// the first request for key. f must be the function identified by key.
+func (c *PolyCache) Chebyshev(key PolyKey, f func(float64) float64) bignum.Polynomial {
+	poly, _ := c.Poly(key, func() (bignum.Polynomial, error) {
+		return chebyshevInterval(key.A, key.B, key.Nodes, f), nil
+	})
+	return poly
+}
+
+// Poly returns the polynomial cached for key, generating it with build on the first request.
+func (c *PolyCache) Poly(key PolyKey, build func() (bignum.Polynomial, error)) (bignum.Polynomial, error) {
+	poly, _, err := c.PolyError(key, func() (bignum.Polynomial, float64, error) {
+		poly, err := build()
+		return poly, 0, err
+	})
+	return poly, err
+}
+
+// PolyError is Poly for a polynomial generated with its maximum error, e.g. by RemezPoly. The
+// error is cached, and saved, along with the polynomial.
+func (c *PolyCache) PolyError(key PolyKey, build func() (bignum.Polynomial, float64, error)) (bignum.Polynomial, float64, error) {
+	c.mu.RLock()
+	cached, ok := c.polys[key]
+	c.mu.RUnlock()
+	if !ok {
+		var err error
+		if cached.poly, cached.maxErr, err = build(); err != nil {
+			return bignum.Polynomial{}, 0, err
+		}
+		c.mu.Lock()
+		c.polys[key] = cached
+		c.mu.Unlock()
+	}
+	return cached.poly.Clone(), cached.maxErr, nil
+}
+
+// polyCacheEntry is the serialized form of a cached polynomial: its key, the interval of its
+// Chebyshev basis, the real and imaginary parts of its coefficients, in decimal at precision
+// Prec, and its maximum error if known.
+type polyCacheEntry struct {
+	Key      PolyKey     `json:"key"`
+	Interval [2]float64  `json:"interval"`
+	Prec     uint        `json:"prec"`
+	Coeffs   [][2]string `json:"coeffs"`
+	MaxErr   float64     `json:"max_err,omitempty"`
+}
+
+// Save writes the cache to path as JSON. The coefficients are written in full precision, so a
+// loaded polynomial is identical to the generated one.
+func (c *PolyCache) Save(path string) error {
+	c.mu.RLock()
+	entries := make([]polyCacheEntry, 0, len(c.polys))
+	for key, cached := range c.polys {
+		poly := cached.poly
+		a, _ := poly.A.Float64()
+		b, _ := poly.B.Float64()
+		entry := polyCacheEntry{Key: key, Interval: [2]float64{a, b}, Coeffs: make([][2]string, len(poly.Coeffs)), MaxErr: cached.maxErr}
+		for i, coeff := range poly.Coeffs {
+			entry.Prec = max(entry.Prec, coeff.Real().Prec())
+			entry.Coeffs[i] = [2]string{coeff.Real().Text('g', -1), coeff.Imag().Text('g', -1)}
+		}
+		entries = append(entries, entry)
+	}
+	c.mu.RUnlock()
+
+	slices.SortFunc(entries, func(x, y polyCacheEntry) int {
+		return cmp.Or(
+			cmp.Compare(x.Key.Func, y.Key.Func),
+			cmp.Compare(x.Key.Mode, y.Key.Mode),
+			cmp.Compare(x.Key.Bound, y.Key.Bound),
+			cmp.Compare(x.Key.A, y.Key.A),
+			cmp.Compare(x.Key.B, y.Key.B),
+			cmp.Compare(x.Key.Nodes, y.Key.Nodes),
+		)
+	})
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encode polynomial cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write polynomial cache: %w", err)
+	}
+	return nil
+}
+
+// Load adds the polynomials saved in path by Save to the cache.
+func (c *PolyCache) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read polynomial cache: %w", err)
+	}
+	var entries []polyCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("decode polynomial cache: %w", err)
+	}
+
+	polys := make(map[PolyKey]cachedPoly, len(entries))
+	for _, entry := range entries {
+		coeffs := make([]*bignum.Complex, len(entry.Coeffs))
+		for i, parts := range entry.Coeffs {
+			coeffs[i] = &bignum.Complex{}
+			for j, s := range parts {
+				v, ok := new(big.Float).SetPrec(entry.Prec).SetString(s)
+				if !ok {
+					return fmt.Errorf("decode polynomial cache: invalid coefficient %q", s)
+				}
+				coeffs[i][j] = v
+			}
+		}
+		interval := &bignum.Interval{
+			A:     *bignum.NewFloat(entry.Interval[0], entry.Prec),
+			B:     *bignum.NewFloat(entry.Interval[1], entry.Prec),
+			Nodes: entry.Key.Nodes - 1,
+		}
+		polys[entry.Key] = cachedPoly{bignum.NewPolynomial(bignum.Chebyshev, coeffs, interval), entry.MaxErr}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, cached := range polys {
+		c.polys[key] = cached
+	}
+	return nil
+}
+
+// LoadPolyCache returns a cache holding the polynomials saved in path by Save.
+func LoadPolyCache(path string) (*PolyCache, error) {
+	c := NewPolyCache()
+	if err := c.Load(path); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// InvSqrtPoly returns the Chebyshev interpolant of ChebyshevInvSqrt for mode and B with the given
+// number of nodes, from the engine's polynomial cache.
+func (e *HEEngine) InvSqrtPoly(mode int, B float64, nodes int) (bignum.Polynomial, error) {
+	F, err := invSqrtTarget(mode, B)
+	if err != nil {
+		return bignum.Polynomial{}, err
+	}
+	if mode == 0 {
+		B = 0
+	}
+	key := PolyKey{Func: "invsqrt", A: -1, B: 1, Nodes: nodes, Bound: B, Mode: mode}
+	return e.Polys.Chebyshev(key, F), nil
+}
+
+// RemezInvSqrtPoly returns the minimax polynomial of RemezInvSqrt_deg for mode and B with the
+// given degree and domain [min, 2], from the engine's polynomial cache, with its maximum relative
+// error on the domain.
+func (e *HEEngine) RemezInvSqrtPoly(mode int, B float64, degree int, min float64) (bignum.Polynomial, float64, error) {
+	F, err := invSqrtTarget(mode, B)
+	if err != nil {
+		return bignum.Polynomial{}, 0, err
+	}
+	if !(min > 0 && min < 2) {
+		return bignum.Polynomial{}, 0, fmt.Errorf("invalid Remez InvSqrt domain [%g, 2]", min)
+	}
+	if mode == 0 {
+		B = 0
+	}
+	key := PolyKey{Func: "invsqrt-remez", A: min - 1, B: 1, Nodes: degree + 1, Bound: B, Mode: mode}
+	return e.Polys.PolyError(key, func() (bignum.Polynomial, float64, error) {
+		return RemezPoly(F, [][2]float64{{min - 1, 1}}, degree, true)
+	})
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+func TestPolyCache(t *testing.T) {
+	c := NewPolyCache()
+	key := PolyKey{Func: "exp", A: -1, B: 1, Nodes: 32}
+	poly := c.Chebyshev(key, math.Exp)
+	if got := poly.Degree(); got != key.Nodes-1 {
+		t.Errorf("degree %d for %d nodes", got, key.Nodes)
+	}
+
+	// A cached key is not built again
+	if _, err := c.Poly(key, func() (bignum.Polynomial, error) {
+		t.Error("cached polynomial rebuilt")
+		return poly, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "polys.json")
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPolyCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 1 {
+		t.Fatalf("loaded %d polynomials, want 1", loaded.Len())
+	}
+	got, err := loaded.Poly(key, func() (bignum.Polynomial, error) {
+		t.Fatal("saved polynomial missing after Load")
+		return bignum.Polynomial{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Coeffs) != len(poly.Coeffs) || got.A.Cmp(&poly.A) != 0 || got.B.Cmp(&poly.B) != 0 {
+		t.Fatalf("loaded polynomial of degree %d on [%v, %v], want degree %d on [%v, %v]",
+			got.Degree(), &got.A, &got.B, poly.Degree(), &poly.A, &poly.B)
+	}
+	for i := range poly.Coeffs {
+		if got.Coeffs[i].Real().Cmp(poly.Coeffs[i].Real()) != 0 || got.Coeffs[i].Imag().Cmp(poly.Coeffs[i].Imag()) != 0 {
+			t.Fatalf("coefficient %d = %v, want %v", i, got.Coeffs[i], poly.Coeffs[i])
+		}
+	}
+	for _, x := range []float64{-1, 0, 0.5, 1} {
+		y, _ := got.Evaluate(x).Real().Float64()
+		checkScalar(t, "loaded exp", []float64{y}, math.Exp(x), 1e-12)
+	}
+}
+
+func TestPolyCacheError(t *testing.T) {
+	c := NewPolyCache()
+	key := PolyKey{Func: "exp-remez", A: -1, B: 1, Nodes: 6}
+	build := func() (bignum.Polynomial, float64, error) {
+		return RemezPoly(math.Exp, [][2]float64{{-1, 1}}, 5, false)
+	}
+	_, want, err := c.PolyError(key, build)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "polys.json")
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPolyCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, got, err := loaded.PolyError(key, func() (bignum.Polynomial, float64, error) {
+		t.Fatal("saved polynomial missing after Load")
+		return build()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("loaded maximum error %g, want %g", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// spearmanB is the scaling constant used when feeding normalized ranks (r/n in (0, 1]) into the
+// Pearson pipeline: their variance is (n²-1)/(12n²) ≈ 1/12, well inside the (0, 2] domain of
+// the inverse square root for B = 0.5.
+const spearmanB = 0.5
+
+// Ranks computes the encrypted (average) ranks 1..n of the values in ct.
+//
+// Every value is compared with every other value through Step((xᵢ - xⱼ)/(2·bound)), so the
+// ranks of tied values are averaged exactly. bound must satisfy |x| ≤ bound, and iter is the
+// number of sign compositions (see Sign): two distinct values xᵢ ≠ xⱼ are ordered correctly
+// up to 2^-20 when |xᵢ - xⱼ|/(2·bound) ≥ 2^-(iter-1).
+//
+// The data must fit in one ciphertext with n ≤ (Slots-1)/2. The n(n-1) comparisons are packed
+// k = min(⌊Slots/n⌋-1, ⌊Slots/(n+1)⌋) rotations at a time, so Ranks evaluates ⌈(n-1)/k⌉ sign
+// polynomials of depth 3·iter each. For Slots = 2^15 this is 59 evaluations for n = 1,338
+// (Insurance) and a single one for n ≤ 181.
+func (e *HEEngine) Ranks(ct *HEData, bound float64, iter int) (*HEData, error) {
+	return e.ranks(ct, bound, iter, 1.0)
+}
+
+// ranks returns the ranks of ct multiplied by scale.
+func (e *HEEngine) ranks(ct *HEData, bound float64, iter int, scale float64) (*HEData, error) {
+	n := ct.Size()
+	slots := e.params.MaxSlots()
+	if len(ct.Ciphertexts()) != 1 || n < 2 || n > (slots-1)/2 {
+		return nil, fmt.Errorf("ranks support 2 ≤ n ≤ %d values in one ciphertext, got %d", (slots-1)/2, n)
+	}
+
+	// Layout: blocks of width w = n+1 hold xᵢ in their first n slots (the last slot is unused),
+	// and the block b of chunk c is compared against x rotated by r = 1 + b + c·k.
+	w := n + 1
+	copies := slots / n
+	k := min(copies-1, slots/w)
+	chunks := (n - 2 + k) / k
+
+	// Step 1: Scale x into [-1/2, 1/2] so that all differences lie in [-1, 1]
+	xs, err := e.MultConst(ct, 1.0/(2*bound))
+	if err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+
+	// Step 2: Left operand: k blocks of [x, 0]
+	left, err := e.sumShifted(xs, -w, k)
+	if err != nil {
+		return nil, fmt.Errorf("tile left operand: %w", err)
+	}
+
+	// Step 3: Right operand: x tiled with period n, so that rotating it by r shifts the
+	// block b by r + b
+	tiled, err := e.sumShifted(xs, -n, copies)
+	if err != nil {
+		return nil, fmt.Errorf("tile right operand: %w", err)
+	}
+
+	// Step 4: Count, for every xᵢ, the values it exceeds
+	var count *HEData
+	for c := 0; c < chunks; c++ {
+		right, err := e.Rotate(tiled, 1+c*k)
+		if err != nil {
+			return nil, fmt.Errorf("rotate chunk %d: %w", c, err)
+		}
+		diff, err := e.Sub(left, right)
+		if err != nil {
+			return nil, fmt.Errorf("difference chunk %d: %w", c, err)
+		}
+		step, err := e.Step(diff, iter)
+		if err != nil {
+			return nil, fmt.Errorf("step chunk %d: %w", c, err)
+		}
+
+		// The last chunk may reach past r = n-1; drop the duplicated comparisons
+		if blocks := n - 1 - c*k; blocks < k {
+			mask := make([]float64, blocks*w)
+			for i := range mask {
+				mask[i] = 1
+			}
+			if step, err = e.MultPlain(step, mask); err != nil {
+				return nil, fmt.Errorf("mask chunk %d: %w", c, err)
+			}
+		}
+
+		if count == nil {
+			count = step
+		} else if count, err = e.Add(count, step); err != nil {
+			return nil, fmt.Errorf("accumulate chunk %d: %w", c, err)
+		}
+	}
+
+	// Step 5: Fold the k blocks into the first one
+	count, err = e.sumShifted(count, w, k)
+	if err != nil {
+		return nil, fmt.Errorf("fold blocks: %w", err)
+	}
+
+	// Step 6: rank = 1 + #{j : xⱼ < xᵢ} + #{j ≠ i : xⱼ = xᵢ}/2, restricted to the n valid slots
+	rank, err := e.AddConst(count, 1.0)
+	if err != nil {
+		return nil, fmt.Errorf("add self rank: %w", err)
+	}
+	mask := make([]float64, n)
+	for i := range mask {
+		mask[i] = scale
+	}
+	rank, err = e.MultPlain(rank, mask)
+	if err != nil {
+		return nil, fmt.Errorf("mask ranks: %w", err)
+	}
+
+	return NewHEData(rank.Ciphertexts(), n, rank.Level(), ct.Scale()), nil
+}
+
+// SpearmanCorr computes Spearman's rank correlation coefficient of ct1 and ct2 by ranking both
+// columns with Ranks and feeding the normalized ranks into PCorrCoeff. Both inputs must hold
+// the same number of values, bounded in magnitude by bound1 and bound2 respectively.
+//
+// Accuracy is that of PCorrCoeff as long as every pair of distinct values is separated by at
+// least 2·bound·2^-(iter-1); closer pairs are counted as partial ties. The cost is two calls to
+// Ranks plus one PCorrCoeff, and the rank vectors are bootstrapped once before the latter.
+func (e *HEEngine) SpearmanCorr(ct1, ct2 *HEData, bound1, bound2 float64, iter int, s InvSqrtStrategy) (*HEData, error) {
+	if ct1.Size() != ct2.Size() {
+		return nil, fmt.Errorf("size mismatch: %d vs %d", ct1.Size(), ct2.Size())
+	}
+	n := float64(ct1.Size())
+
+	// Step 1: Ranks normalized to (0, 1]
+	rank1, err := e.ranks(ct1, bound1, iter, 1.0/n)
+	if err != nil {
+		return nil, fmt.Errorf("ranks of ct1: %w", err)
+	}
+	rank2, err := e.ranks(ct2, bound2, iter, 1.0/n)
+	if err != nil {
+		return nil, fmt.Errorf("ranks of ct2: %w", err)
+	}
+
+	// Step 2: Refresh the ranks for the Pearson pipeline
+	if e.IsBTS {
+		if rank1, err = e.DoBootstrap(rank1, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (ranks of ct1): %w", err)
+		}
+		if rank2, err = e.DoBootstrap(rank2, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (ranks of ct2): %w", err)
+		}
+	}
+
+	// Step 3: Pearson correlation of the ranks
+	return e.PCorrCoeff(rank1, rank2, spearmanB, s)
+}
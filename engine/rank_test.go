@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// plainRanks returns the average ranks 1..n of data.
+func plainRanks(data []float64) []float64 {
+	ranks := make([]float64, len(data))
+	for i, x := range data {
+		ranks[i] = 1
+		for j, y := range data {
+			if y < x {
+				ranks[i]++
+			} else if y == x && j != i {
+				ranks[i] += 0.5
+			}
+		}
+	}
+	return ranks
+}
+
+// rankData returns a permutation of 1..n with one tie, so that distinct values differ by 1.
+func rankData(seed int64, n int) []float64 {
+	data := make([]float64, n)
+	for i, p := range rand.New(rand.NewSource(seed)).Perm(n) {
+		data[i] = float64(p + 1)
+	}
+	data[n-1] = data[0]
+	return data
+}
+
+func TestRanks(t *testing.T) {
+	e := testEngine(t)
+	data := rankData(1, 24)
+
+	ranks, err := e.Ranks(encryptTest(t, e, data), 25, 8)
+	got := decryptTest(t, e, ranks, err)
+	checkClose(t, "ranks", got, plainRanks(data), 1e-3)
+}
+
+func TestSpearmanCorr(t *testing.T) {
+	e := testEngine(t)
+	x := rankData(2, 24)
+	y := make([]float64, len(x))
+	for i := range y {
+		y[i] = x[i] + float64(i%5) - 2
+	}
+
+	corr, err := e.SpearmanCorr(encryptTest(t, e, x), encryptTest(t, e, y), 25, 30, 8, PPStat{})
+	got := decryptTest(t, e, corr, err)
+	_, want, _ := utils.Correlation(plainRanks(x), plainRanks(y))
+	checkScalar(t, "Spearman", got, want, 1e-3)
+}
@@ -0,0 +1,334 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// LinearModel is an encrypted ordinary least squares fit y ≈ Intercept + Σⱼ Coefficients[j]·X[j].
+// Every field holds its value replicated in all slots of a single ciphertext.
+type LinearModel struct {
+	Coefficients []*HEData
+	Intercept    *HEData
+	RSquared     *HEData
+}
+
+// LinearRegression fits y on the columns X by ordinary least squares.
+//
+// The normal equations are solved on standardized features: the encrypted Gram matrices XᵀX and
+// Xᵀy of the centered columns are scaled by 1/σ (BatchedInvStd) into the correlation
+// matrix R and the correlation vector t, so that R has a unit diagonal and eigenvalues in (0, p].
+// R⁻¹ is then computed with iter Newton–Schulz steps V ← V(2I - RV) from the plaintext guess
+// V₀ = I/p, whose error contracts as (1 - λmin(R)/p)^(2^iter). With a single column R = 1 and no
+// inversion is needed: the slope reduces to cov(x, y)·(1/σx)².
+//
+// B is the scaling constant of the inverse square root and must suit every column and y.
+func (e *HEEngine) LinearRegression(X []*HEData, y *HEData, B float64, iter int, s InvSqrtStrategy) (*LinearModel, error) {
+	p := len(X)
+	if p == 0 {
+		return nil, fmt.Errorf("no feature columns")
+	}
+	for j := range X {
+		if X[j].Size() != y.Size() {
+			return nil, fmt.Errorf("size mismatch in column %d: %d vs %d", j, X[j].Size(), y.Size())
+		}
+	}
+	cols := append(append([]*HEData{}, X...), y)
+	invN := 1.0 / float64(y.Size())
+
+	// Step 1: Means, centered columns and inverse standard deviations
+	means := make([]*HEData, p+1)
+	centered := make([]*HEData, p+1)
+	for j, col := range cols {
+		mean, err := e.Mean(col)
+		if err != nil {
+			return nil, fmt.Errorf("mean of column %d: %w", j, err)
+		}
+		if centered[j], err = e.Sub(col, mean); err != nil {
+			return nil, fmt.Errorf("center column %d: %w", j, err)
+		}
+		if means[j], err = e.selectOneCtxt(mean); err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (mean %d): %w", j, err)
+		}
+	}
+	invStd, err := e.BatchedInvStd(cols, B, s)
+	if err != nil {
+		return nil, fmt.Errorf("BatchedInvStd: %w", err)
+	}
+	if e.IsBTS {
+		for j := range invStd {
+			if invStd[j], err = e.DoBootstrap(invStd[j], e.params.MaxLevel()); err != nil {
+				return nil, fmt.Errorf("bootstrap (1/σ of column %d): %w", j, err)
+			}
+		}
+	}
+
+	// Step 2: Gram matrix of the centered columns, scaled to correlations
+	// corr[j][k] = <xⱼ - μⱼ, xₖ - μₖ>/n · (1/σⱼ)(1/σₖ), with corr[j][p] = t
+	cov := func(j, k int) (*HEData, error) {
+		ip, err := e.InnerProduct(centered[j], centered[k])
+		if err != nil {
+			return nil, err
+		}
+		if ip, err = e.selectOneCtxt(ip); err != nil {
+			return nil, err
+		}
+		return e.MultConst(ip, invN)
+	}
+	corr := make([][]*HEData, p+1)
+	for j := range corr {
+		corr[j] = make([]*HEData, p+1)
+	}
+	var covY *HEData
+	for j := 0; j < p; j++ {
+		for k := j + 1; k <= p; k++ {
+			c, err := cov(j, k)
+			if err != nil {
+				return nil, fmt.Errorf("covariance (%d, %d): %w", j, k, err)
+			}
+			if k == p && p == 1 {
+				covY = c
+			}
+			invProd, err := e.Mult(invStd[j], invStd[k])
+			if err != nil {
+				return nil, fmt.Errorf("1/σ product (%d, %d): %w", j, k, err)
+			}
+			if corr[j][k], err = e.Mult(c, invProd); err != nil {
+				return nil, fmt.Errorf("correlation (%d, %d): %w", j, k, err)
+			}
+			if e.IsBTS {
+				if corr[j][k], err = e.DoBootstrap(corr[j][k], e.params.MaxLevel()); err != nil {
+					return nil, fmt.Errorf("bootstrap (correlation (%d, %d)): %w", j, k, err)
+				}
+			}
+			corr[k][j] = corr[j][k]
+		}
+	}
+	t := make([]*HEData, p)
+	for j := range t {
+		t[j] = corr[j][p]
+	}
+
+	var slopes []*HEData
+	var gamma []*HEData
+
+	if p == 1 {
+		// Simple regression: slope = cov(x, y)·(1/σx)², R² = t²
+		invVar, err := e.Mult(invStd[0], invStd[0])
+		if err != nil {
+			return nil, fmt.Errorf("1/σx²: %w", err)
+		}
+		slope, err := e.Mult(covY, invVar)
+		if err != nil {
+			return nil, fmt.Errorf("slope: %w", err)
+		}
+		slopes, gamma = []*HEData{slope}, t
+	} else {
+		// Step 3: V ≈ R⁻¹ by Newton–Schulz, standardized coefficients γ = V·t
+		inv, err := e.newtonSchulzCorr(corr, p, iter)
+		if err != nil {
+			return nil, fmt.Errorf("Newton–Schulz: %w", err)
+		}
+		if gamma, err = e.matVec(inv, t); err != nil {
+			return nil, fmt.Errorf("standardized coefficients: %w", err)
+		}
+
+		// Step 4: Back to the original units: βⱼ = γⱼ·σy·(1/σⱼ), σy = cov(y, y)·(1/σy)
+		varY, err := cov(p, p)
+		if err != nil {
+			return nil, fmt.Errorf("variance of y: %w", err)
+		}
+		sigmaY, err := e.Mult(varY, invStd[p])
+		if err != nil {
+			return nil, fmt.Errorf("σy: %w", err)
+		}
+		slopes = make([]*HEData, p)
+		for j := range slopes {
+			unit, err := e.Mult(sigmaY, invStd[j])
+			if err != nil {
+				return nil, fmt.Errorf("σy/σ (%d): %w", j, err)
+			}
+			if slopes[j], err = e.Mult(gamma[j], unit); err != nil {
+				return nil, fmt.Errorf("coefficient %d: %w", j, err)
+			}
+		}
+	}
+
+	// Step 5: Intercept = μy - Σⱼ βⱼ·μⱼ
+	intercept := means[p]
+	for j := range slopes {
+		term, err := e.Mult(slopes[j], means[j])
+		if err != nil {
+			return nil, fmt.Errorf("β·μ (%d): %w", j, err)
+		}
+		if intercept, err = e.Sub(intercept, term); err != nil {
+			return nil, fmt.Errorf("intercept: %w", err)
+		}
+	}
+
+	// Step 6: R² = Σⱼ γⱼ·tⱼ
+	var rSquared *HEData
+	for j := range gamma {
+		term, err := e.Mult(gamma[j], t[j])
+		if err != nil {
+			return nil, fmt.Errorf("γ·t (%d): %w", j, err)
+		}
+		if rSquared == nil {
+			rSquared = term
+		} else if rSquared, err = e.Add(rSquared, term); err != nil {
+			return nil, fmt.Errorf("R²: %w", err)
+		}
+	}
+
+	return &LinearModel{Coefficients: slopes, Intercept: intercept, RSquared: rSquared}, nil
+}
+
+// newtonSchulzCorr approximates the inverse of the p×p correlation matrix corr[:p][:p], whose
+// diagonal is implicitly 1, with iter Newton–Schulz steps started from V₀ = I/p.
+func (e *HEEngine) newtonSchulzCorr(corr [][]*HEData, p, iter int) ([][]*HEData, error) {
+	alpha := 1.0 / float64(p)
+
+	// Step 1: V₁ = 2αI - α²R, so that V₀ never has to be encrypted
+	inv := make([][]*HEData, p)
+	for j := range inv {
+		inv[j] = make([]*HEData, p)
+		for k := range inv[j] {
+			var err error
+			if j == k {
+				// R_jj = 1: the constant is encrypted at the level of the off-diagonal entries
+				if inv[j][k], err = e.encryptConst(corr[j][(j+1)%p], 2*alpha-alpha*alpha, 1); err != nil {
+					return nil, err
+				}
+			} else if inv[j][k], err = e.MultConst(corr[j][k], -alpha*alpha); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Each iteration consumes two levels, and the caller needs three more for γ, β and the
+	// intercept
+	refresh := func(it int) error {
+		if !e.IsBTS {
+			return nil
+		}
+		for j := range inv {
+			for k := range inv[j] {
+				var err error
+				if inv[j][k], err = e.DoBootstrap(inv[j][k], 3); err != nil {
+					return fmt.Errorf("bootstrap (iteration %d): %w", it, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	// Step 2: V ← 2V - V(RV)
+	for it := 1; it < iter; it++ {
+		if err := refresh(it); err != nil {
+			return nil, err
+		}
+
+		rv, err := e.matMulCorr(corr, inv, p)
+		if err != nil {
+			return nil, fmt.Errorf("RV (iteration %d): %w", it, err)
+		}
+		vrv, err := e.matMul(inv, rv)
+		if err != nil {
+			return nil, fmt.Errorf("V(RV) (iteration %d): %w", it, err)
+		}
+		for j := range inv {
+			for k := range inv[j] {
+				twice, err := e.Add(inv[j][k], inv[j][k])
+				if err != nil {
+					return nil, err
+				}
+				if inv[j][k], err = e.Sub(twice, vrv[j][k]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if err := refresh(iter); err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+// encryptConst encrypts c in the valid slots of a ciphertext shaped like ref, depth levels below it.
+func (e *HEEngine) encryptConst(ref *HEData, c float64, depth int) (*HEData, error) {
+	values := make([]float64, ref.Size())
+	for i := range values {
+		values[i] = c
+	}
+	ct, err := e.Encrypt(values, ref.Level()-depth)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt constant: %w", err)
+	}
+	return ct, nil
+}
+
+// matMulCorr returns R·M where R is the correlation matrix stored in corr with a unit diagonal.
+func (e *HEEngine) matMulCorr(corr, m [][]*HEData, p int) ([][]*HEData, error) {
+	out := make([][]*HEData, p)
+	for j := 0; j < p; j++ {
+		out[j] = make([]*HEData, len(m[0]))
+		for k := range out[j] {
+			acc := m[j][k]
+			for l := 0; l < p; l++ {
+				if l == j {
+					continue
+				}
+				term, err := e.Mult(corr[j][l], m[l][k])
+				if err != nil {
+					return nil, err
+				}
+				if acc, err = e.Add(acc, term); err != nil {
+					return nil, err
+				}
+			}
+			out[j][k] = acc
+		}
+	}
+	return out, nil
+}
+
+// matMul returns the product of two matrices of encrypted scalars.
+func (e *HEEngine) matMul(a, b [][]*HEData) ([][]*HEData, error) {
+	out := make([][]*HEData, len(a))
+	for j := range a {
+		out[j] = make([]*HEData, len(b[0]))
+		for k := range out[j] {
+			var acc *HEData
+			for l := range b {
+				term, err := e.Mult(a[j][l], b[l][k])
+				if err != nil {
+					return nil, err
+				}
+				if acc == nil {
+					acc = term
+				} else if acc, err = e.Add(acc, term); err != nil {
+					return nil, err
+				}
+			}
+			out[j][k] = acc
+		}
+	}
+	return out, nil
+}
+
+// matVec returns the product of a matrix and a vector of encrypted scalars.
+func (e *HEEngine) matVec(a [][]*HEData, v []*HEData) ([]*HEData, error) {
+	col := make([][]*HEData, len(v))
+	for j := range v {
+		col[j] = []*HEData{v[j]}
+	}
+	prod, err := e.matMul(a, col)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*HEData, len(prod))
+	for j := range prod {
+		out[j] = prod[j][0]
+	}
+	return out, nil
+}
@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// plainOLS returns the least squares slopes, intercept and R² of y on two columns.
+func plainOLS(x1, x2, y []float64) ([]float64, float64, float64) {
+	c11 := utils.Variance(x1)
+	c22 := utils.Variance(x2)
+	c12, _ := utils.Covariance(x1, x2)
+	c1y, _ := utils.Covariance(x1, y)
+	c2y, _ := utils.Covariance(x2, y)
+
+	det := c11*c22 - c12*c12
+	b1 := (c22*c1y - c12*c2y) / det
+	b2 := (c11*c2y - c12*c1y) / det
+	intercept := utils.Mean(y) - b1*utils.Mean(x1) - b2*utils.Mean(x2)
+	rSquared := (b1*c1y + b2*c2y) / utils.Variance(y)
+	return []float64{b1, b2}, intercept, rSquared
+}
+
+func TestLinearRegression(t *testing.T) {
+	e := testEngine(t)
+	x1 := normalData(1, 200, 1, 2)
+	x2 := normalData(2, 200, -1, 1.5)
+	noise := normalData(3, 200, 0, 1)
+	y := make([]float64, len(x1))
+	for i := range y {
+		x2[i] += 0.3 * x1[i]
+		y[i] = 0.5 + 1.5*x1[i] - 2*x2[i] + noise[i]
+	}
+
+	model, err := e.LinearRegression([]*HEData{encryptTest(t, e, x1), encryptTest(t, e, x2)}, encryptTest(t, e, y), 10, 5, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slopes, intercept, rSquared := plainOLS(x1, x2, y)
+	for j, want := range slopes {
+		checkScalar(t, "slope", decryptTest(t, e, model.Coefficients[j], nil), want, 1e-3)
+	}
+	checkScalar(t, "intercept", decryptTest(t, e, model.Intercept, nil), intercept, 1e-3)
+	checkScalar(t, "R²", decryptTest(t, e, model.RSquared, nil), rSquared, 1e-3)
+}
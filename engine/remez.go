@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// remezMaxIter bounds the exchange iterations of RemezPoly.
+const remezMaxIter = 100
+
+// remezGridPerNode is the number of scan points per reference point on which RemezPoly locates
+// the extrema of the error.
+const remezGridPerNode = 32
+
+// remezTolerance stops RemezPoly once the levelled error of the reference is within this
+// fraction of the maximum error on the scan grid, i.e. once the polynomial is this close to
+// minimax.
+const remezTolerance = 1e-4
+
+// RemezPoly returns the minimax polynomial of the given degree for f on a union of disjoint
+// intervals of [-1, 1], in Chebyshev basis on [-1, 1], with its maximum error. With relative,
+// the error minimized is the relative error |1 - p/f|, and f must not vanish on the intervals;
+// otherwise it is the absolute error |f - p|.
+//
+// The multi-interval Remez exchange alternates between solving for the polynomial with a levelled
+// weighted error on n+2 reference points and moving the reference to the alternating extrema of
+// the error on a grid. The returned error is measured on a grid four times finer. An exchange
+// that does not level the error within remezTolerance in remezMaxIter iterations, or that loses
+// the alternation, returns an error with the errors reached.
+func RemezPoly(f func(float64) float64, intervals [][2]float64, degree int, relative bool) (bignum.Polynomial, float64, error) {
+	if degree < 0 {
+		return bignum.Polynomial{}, 0, fmt.Errorf("invalid Remez degree: %d", degree)
+	}
+	if len(intervals) == 0 {
+		return bignum.Polynomial{}, 0, fmt.Errorf("no Remez interval")
+	}
+	intervals = slices.Clone(intervals)
+	slices.SortFunc(intervals, func(x, y [2]float64) int {
+		return cmp.Compare(x[0], y[0])
+	})
+	for i, iv := range intervals {
+		if !(iv[0] < iv[1] && iv[0] >= -1 && iv[1] <= 1) {
+			return bignum.Polynomial{}, 0, fmt.Errorf("invalid Remez interval [%g, %g]", iv[0], iv[1])
+		}
+		if i > 0 && iv[0] <= intervals[i-1][1] {
+			return bignum.Polynomial{}, 0, fmt.Errorf("overlapping Remez intervals [%g, %g] and [%g, %g]", intervals[i-1][0], intervals[i-1][1], iv[0], iv[1])
+		}
+	}
+	n := degree + 2
+
+	// Step 1: Scan grid, weights and function values
+	xs, ws, fs, err := remezGrid(f, intervals, remezGridPerNode*n, relative)
+	if err != nil {
+		return bignum.Polynomial{}, 0, err
+	}
+
+	// Step 2: Exchange, from a reference spread evenly over the grid
+	ref := make([]int, n)
+	for j := range ref {
+		ref[j] = j * (len(xs) - 1) / (n - 1)
+	}
+	var coeffs []float64
+	var levelled, maxErr float64
+	converged := false
+	errs := make([]float64, len(xs))
+	for range remezMaxIter {
+		// Solve Σ c_k T_k(x_j) + (-1)^j E/w_j = f(x_j)
+		A := make([][]float64, n)
+		rhs := make([]float64, n)
+		for j, k := range ref {
+			A[j] = make([]float64, n)
+			chebyshevBasis(xs[k], A[j][:n-1])
+			A[j][n-1] = math.Pow(-1, float64(j)) / ws[k]
+			rhs[j] = fs[k]
+		}
+		sol, err := solveLinear(A, rhs)
+		if err != nil {
+			return bignum.Polynomial{}, 0, fmt.Errorf("remez: %w", err)
+		}
+		coeffs = sol[:n-1]
+		levelled, maxErr = math.Abs(sol[n-1]), 0
+
+		for k, x := range xs {
+			errs[k] = ws[k] * (fs[k] - chebyshevEval(coeffs, x))
+			maxErr = max(maxErr, math.Abs(errs[k]))
+		}
+		if converged = maxErr-levelled <= remezTolerance*maxErr; converged {
+			break
+		}
+		next := remezExchange(errs, n)
+		if len(next) < n {
+			break
+		}
+		ref = next
+	}
+	if !converged {
+		return bignum.Polynomial{}, 0, fmt.Errorf("remez: no convergence at degree %d: maximum error %g, levelled error %g", degree, maxErr, levelled)
+	}
+
+	// Step 3: Error on a finer grid
+	xs, ws, fs, err = remezGrid(f, intervals, 4*remezGridPerNode*n, relative)
+	if err != nil {
+		return bignum.Polynomial{}, 0, err
+	}
+	maxErr = 0
+	for k, x := range xs {
+		maxErr = max(maxErr, ws[k]*math.Abs(fs[k]-chebyshevEval(coeffs, x)))
+	}
+
+	return bignum.NewPolynomial(bignum.Chebyshev, coeffs, [2]float64{-1, 1}), maxErr, nil
+}
+
+// remezGrid returns about size Chebyshev–Lobatto points over the intervals, in increasing order and
+// shared in proportion to the interval lengths, with the error weights and the values of f.
+func remezGrid(f func(float64) float64, intervals [][2]float64, size int, relative bool) (xs, ws, fs []float64, err error) {
+	total := 0.0
+	for _, iv := range intervals {
+		total += iv[1] - iv[0]
+	}
+	for _, iv := range intervals {
+		m := max(2, int(math.Ceil(float64(size)*(iv[1]-iv[0])/total)))
+		for k := range m {
+			x := (iv[0]+iv[1])/2 - (iv[1]-iv[0])/2*math.Cos(math.Pi*float64(k)/float64(m-1))
+			y := f(x)
+			w := 1.0
+			if relative {
+				if y == 0 || math.IsNaN(y) || math.IsInf(y, 0) {
+					return nil, nil, nil, fmt.Errorf("relative error undefined at %g: f = %g", x, y)
+				}
+				w = 1 / math.Abs(y)
+			}
+			xs, ws, fs = append(xs, x), append(ws, w), append(fs, y)
+		}
+	}
+	return xs, ws, fs, nil
+}
+
+// remezExchange returns n grid indices where the error alternates in sign with the largest
+// magnitudes: the extremum of every run of constant sign, trimmed by dropping the smallest
+// extrema, in adjacent pairs inside the sequence so that the signs keep alternating.
+func remezExchange(errs []float64, n int) []int {
+	var ref []int
+	for k, v := range errs {
+		last := len(ref) - 1
+		switch {
+		case last < 0 || (v >= 0) != (errs[ref[last]] >= 0):
+			ref = append(ref, k)
+		case math.Abs(v) > math.Abs(errs[ref[last]]):
+			ref[last] = k
+		}
+	}
+	for len(ref) > n {
+		last := len(ref) - 1
+		if len(ref) == n+1 {
+			if math.Abs(errs[ref[0]]) < math.Abs(errs[ref[last]]) {
+				ref = ref[1:]
+			} else {
+				ref = ref[:last]
+			}
+			continue
+		}
+		i := 0
+		for j := range ref {
+			if math.Abs(errs[ref[j]]) < math.Abs(errs[ref[i]]) {
+				i = j
+			}
+		}
+		switch {
+		case i == 0 || i == last:
+			ref = slices.Delete(ref, i, i+1)
+		case math.Abs(errs[ref[i-1]]) < math.Abs(errs[ref[i+1]]):
+			ref = slices.Delete(ref, i-1, i+1)
+		default:
+			ref = slices.Delete(ref, i, i+2)
+		}
+	}
+	return ref
+}
+
+// chebyshevBasis writes T_0(x), ..., T_{len(out)-1}(x) to out.
+func chebyshevBasis(x float64, out []float64) {
+	for k := range out {
+		switch k {
+		case 0:
+			out[k] = 1
+		case 1:
+			out[k] = x
+		default:
+			out[k] = 2*x*out[k-1] - out[k-2]
+		}
+	}
+}
+
+// chebyshevEval evaluates Σ c_k T_k(x) with Clenshaw's recurrence.
+func chebyshevEval(coeffs []float64, x float64) float64 {
+	var b1, b2 float64
+	for k := len(coeffs) - 1; k >= 1; k-- {
+		b1, b2 = 2*x*b1-b2+coeffs[k], b1
+	}
+	return x*b1 - b2 + coeffs[0]
+}
+
+// solveLinear solves A·x = b by Gaussian elimination with partial pivoting, in place.
+func solveLinear(A [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(A[row][col]) > math.Abs(A[pivot][col]) {
+				pivot = row
+			}
+		}
+		if A[pivot][col] == 0 {
+			return nil, fmt.Errorf("singular system")
+		}
+		A[col], A[pivot] = A[pivot], A[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < n; row++ {
+			r := A[row][col] / A[col][col]
+			for k := col; k < n; k++ {
+				A[row][k] -= r * A[col][k]
+			}
+			b[row] -= r * b[col]
+		}
+	}
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		s := b[row]
+		for k := row + 1; k < n; k++ {
+			s -= A[row][k] * x[k]
+		}
+		x[row] = s / A[row][row]
+	}
+	return x, nil
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestRemezPoly(t *testing.T) {
+	// The minimax error of e^x at degree 5 on [-1, 1] is about 4.5e-5, slightly below the error
+	// of the Chebyshev interpolant
+	poly, maxErr, err := RemezPoly(math.Exp, [][2]float64{{-1, 1}}, 5, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cheb := GetChebyshevPoly(1.0, 5, math.Exp)
+	chebErr := 0.0
+	for _, x := range utils.Linspace(-1, 1, 1001) {
+		y, _ := poly.Evaluate(x).Real().Float64()
+		if d := math.Abs(y - math.Exp(x)); d > maxErr*(1+remezTolerance) {
+			t.Fatalf("error %g at %g above the returned bound %g", d, x, maxErr)
+		}
+		c, _ := cheb.Evaluate(x).Real().Float64()
+		chebErr = max(chebErr, math.Abs(c-math.Exp(x)))
+	}
+	if !(maxErr < chebErr && maxErr > 4e-5 && maxErr < 5e-5) {
+		t.Errorf("minimax error %g, Chebyshev interpolant error %g", maxErr, chebErr)
+	}
+
+	// Relative error on two intervals around the singularity of 1/x
+	inverse := func(x float64) float64 { return 1 / x }
+	if _, maxErr, err = RemezPoly(inverse, [][2]float64{{-1, -0.25}, {0.25, 1}}, 15, true); err != nil {
+		t.Fatal(err)
+	}
+	if !(maxErr < 0.1) {
+		t.Errorf("relative error %g of 1/x at degree 15", maxErr)
+	}
+}
+
+func TestRemezPolyNoConvergence(t *testing.T) {
+	// √|x| has no alternating error of the right length at degree 30 on the scan grid
+	sqrtAbs := func(x float64) float64 { return math.Sqrt(math.Abs(x)) }
+	if _, _, err := RemezPoly(sqrtAbs, [][2]float64{{-1, 1}}, 30, false); err == nil {
+		t.Error("no error for a Remez exchange that does not converge")
+	}
+}
+
+func TestRemezInvSqrt(t *testing.T) {
+	e := testEngine(t)
+	x := utils.Linspace(0.05, 20, 64)
+	want := invSqrtWant(x)
+
+	_, bound, err := e.RemezInvSqrtPoly(2, 10, 62, RemezInvSqrtMin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scaled, err := e.Encrypt(utils.Linspace(0.005, 2, 64), e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	guess, err := e.RemezInvSqrt_deg(scaled, 2, 10, 62, RemezInvSqrtMin)
+	checkClose(t, "Remez guess", decryptTest(t, e, guess, err), want, bound+1e-6)
+
+	half, err := e.Encrypt(utils.Linspace(0.025, 10, 64), e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	invSqrt, err := e.CryptoInvSqrt(half, scaled, 10, 62, 7, 2, 2, RemezInit)
+	checkClose(t, "CryptoInvSqrt (Remez)", decryptTest(t, e, invSqrt, err), want, 1e-4)
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// ColumnBound declares that every value of a column lies in [Lo, Hi].
+type ColumnBound struct {
+	Lo, Hi float64
+}
+
+// BoundB returns the smallest scaling constant B that suits every column whatever its data: the
+// population variance of values in [Lo, Hi] is at most (Hi - Lo)²/4, which is 2B² for
+// B = (Hi - Lo)/(2√2). The result can be passed as B to every statistic over these columns.
+func BoundB(bounds ...ColumnBound) (float64, error) {
+	if len(bounds) == 0 {
+		return 0, fmt.Errorf("no column bounds")
+	}
+	B := 0.0
+	for j, b := range bounds {
+		if !(b.Hi > b.Lo) {
+			return 0, fmt.Errorf("invalid bound [%g, %g] for column %d", b.Lo, b.Hi, j)
+		}
+		B = max(B, (b.Hi-b.Lo)/(2*math.Sqrt2))
+	}
+	return B, nil
+}
+
+// magnitudeSignIter is the Step iteration count of the bucket test, which resolves
+// |y - 1/8| ≥ 1/8 (see Sign).
+const magnitudeSignIter = 5
+
+// AutoScale wraps a strategy with an encrypted coarse magnitude estimate, so that B only has to
+// be an upper bound: 1/√x is computed as √c/√(x·c), where c = 4^k brings x·c/(2B) back to about
+// (1/8, 1] for x down to 2B·4^-Stages/8. The inner strategy then works on a well-conditioned
+// input whatever the spread of the data, instead of losing accuracy when x is much smaller
+// than 2B. Each stage costs a Step of magnitudeSignIter compositions.
+type AutoScale struct {
+	Strategy InvSqrtStrategy
+	Stages   int
+}
+
+// InvSqrt normalizes x by its magnitude and runs the inner strategy on the result.
+func (a AutoScale) InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error) {
+	// Step 1: y = x·c/(2B) and root = (-1/2)^Stages·√c
+	y, root, err := e.magnitudeScale(x, 2*B, a.Stages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: 1/√y, for 0 < y ≤ 1
+	invSqrt, err := a.Strategy.InvSqrt(e, y, 0.5)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: 1/√x = √c·(1/√y)/√(2B)
+	if e.IsBTS {
+		if invSqrt, err = e.DoBootstrap(invSqrt, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/√y): %w", err)
+		}
+		if root, err = e.DoBootstrap(root, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (√c): %w", err)
+		}
+	}
+	if invSqrt, err = e.Mult(invSqrt, root); err != nil {
+		return nil, fmt.Errorf("multiply by √c: %w", err)
+	}
+	return e.MultConst(invSqrt, math.Pow(-2, float64(a.Stages))/math.Sqrt(2*B))
+}
+
+// InvStd computes 1/√Var[X] with InvSqrt.
+func (a AutoScale) InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error) {
+	return invStdOf(e, a, ct, B)
+}
+
+// magnitudeScale returns y = x·c/hi and root = (-1/2)^stages·√c for 0 < x ≤ hi, with c = 4^k and
+// k the number of stages at which the running y was at most 1/8.
+//
+// Every stage computes t = Step(y - 1/8) and multiplies y by (t - 2)² and root by (t - 2)/2. A
+// decided test multiplies y by 1 or 4, and an undecided one, close to the threshold, by a value
+// in between; either way root² = c/4^stages holds, and y stays at most 1 and root at most 1 in
+// magnitude for the bootstrapping. The unused slots hold y = 1, whose test never fires.
+func (e *HEEngine) magnitudeScale(x *HEData, hi float64, stages int) (y, root *HEData, err error) {
+	if stages < 1 {
+		return nil, nil, fmt.Errorf("invalid magnitude stage count: %d", stages)
+	}
+	if y, err = scaleFill(e, x, 1/hi, 1); err != nil {
+		return nil, nil, fmt.Errorf("normalize input: %w", err)
+	}
+
+	for k := range stages {
+		// Step 1: t = Step(y - 1/8), 0 when y must grow
+		shifted, err := e.SubConst(y, 0.125)
+		if err != nil {
+			return nil, nil, fmt.Errorf("threshold (stage %d): %w", k+1, err)
+		}
+		t, err := e.Step(shifted, magnitudeSignIter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bucket test (stage %d): %w", k+1, err)
+		}
+		if e.IsBTS {
+			if t, err = e.DoBootstrap(t, 2); err != nil {
+				return nil, nil, fmt.Errorf("bootstrap (bucket test, stage %d): %w", k+1, err)
+			}
+			if y, err = e.DoBootstrap(y, 1); err != nil {
+				return nil, nil, fmt.Errorf("bootstrap (y, stage %d): %w", k+1, err)
+			}
+		}
+
+		// Step 2: y ← y·(t - 2)², root ← root·(t - 2)/2
+		m, err := e.SubConst(t, 2)
+		if err != nil {
+			return nil, nil, fmt.Errorf("multiplier (stage %d): %w", k+1, err)
+		}
+		m2, err := e.Mult(m, m)
+		if err != nil {
+			return nil, nil, fmt.Errorf("squared multiplier (stage %d): %w", k+1, err)
+		}
+		if y, err = e.Mult(y, m2); err != nil {
+			return nil, nil, fmt.Errorf("scale y (stage %d): %w", k+1, err)
+		}
+		half, err := e.MultConst(m, 0.5)
+		if err != nil {
+			return nil, nil, fmt.Errorf("halve multiplier (stage %d): %w", k+1, err)
+		}
+		if root == nil {
+			root = half
+		} else {
+			if e.IsBTS {
+				if root, err = e.DoBootstrap(root, 1); err != nil {
+					return nil, nil, fmt.Errorf("bootstrap (root, stage %d): %w", k+1, err)
+				}
+			}
+			if root, err = e.Mult(root, half); err != nil {
+				return nil, nil, fmt.Errorf("scale root (stage %d): %w", k+1, err)
+			}
+		}
+	}
+	return y, root, nil
+}
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestBoundB(t *testing.T) {
+	B, err := BoundB(ColumnBound{0, 10}, ColumnBound{-20, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Values at both ends of [-20, 20] reach the largest population variance 20² = 2B²
+	if want := 20 / math.Sqrt2; math.Abs(B-want) > 1e-12 {
+		t.Errorf("B = %g, want %g", B, want)
+	}
+	if _, err := BoundB(ColumnBound{1, 1}); err == nil {
+		t.Error("no error for an empty column bound")
+	}
+}
+
+func TestAutoScale(t *testing.T) {
+	e := testEngine(t)
+	// Three stages rescale inputs down to 2B·4^-3/8 ≈ 0.04 for B = 10
+	s := AutoScale{Strategy: PPStat{}, Stages: 3}
+	checkStrategy(t, e, "AutoScale", s, utils.Linspace(0.05, 20, 50), normalData(1, 300, 2, 0.3), 10, 1e-5)
+}
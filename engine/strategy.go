@@ -0,0 +1,327 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// InvSqrtStrategy is the inverse square root behind the statistics that normalize by a standard
+// deviation (ZScoreNorm, Skewness, Kurtosis, PCorrCoeff, ...). HEStat, PPStat and HEDAP are the
+// methods compared in the paper; InvSqrtFunc plugs in any other one.
+type InvSqrtStrategy interface {
+	// InvSqrt approximates 1/√x in every slot of x, for 0 < x ≤ 2B.
+	InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error)
+	// InvStd approximates 1/σ for the population standard deviation σ of ct, whose population
+	// variance must be at most 2B², replicated in the valid slots of a single ciphertext.
+	InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error)
+}
+
+// InvSqrtFunc is a user-defined strategy: a function approximating 1/√x for 0 < x ≤ 2B.
+// InvStd applies it to the population variance.
+type InvSqrtFunc func(e *HEEngine, x *HEData, B float64) (*HEData, error)
+
+// InvSqrt calls f.
+func (f InvSqrtFunc) InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error) {
+	return f(e, x, B)
+}
+
+// InvStd computes 1/√Var[X] with f.
+func (f InvSqrtFunc) InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error) {
+	return invStdOf(e, f, ct, B)
+}
+
+// invStdOf computes 1/σ as the inverse square root of the population variance, bounded by 2B².
+// The variance is replicated in every slot, so that no slot holds an input outside (0, 2B].
+func invStdOf(e *HEEngine, s InvSqrtStrategy, ct *HEData, B float64) (*HEData, error) {
+	n := float64(ct.Size())
+	variance, err := varianceWithCustomDenom(e, ct, n, n)
+	if err != nil {
+		return nil, fmt.Errorf("variance: %w", err)
+	}
+	if variance, err = e.selectOneCtxt(variance); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (variance): %w", err)
+	}
+	return s.InvSqrt(e, variance, B*B)
+}
+
+// scaleFill returns c·x in the valid slots of x and v in the slots beyond x.Size(), so that an
+// inverse square root does not diverge on the zeros there and spoil the next bootstrapping.
+// Without unused slots it is MultConst(x, c).
+func scaleFill(e *HEEngine, x *HEData, c, v float64) (*HEData, error) {
+	if x.Size()%e.params.MaxSlots() == 0 {
+		return e.MultConst(x, c)
+	}
+	scale := make([]float64, x.Size())
+	for i := range scale {
+		scale[i] = c
+	}
+	shifted, err := e.SubConst(x, v/c)
+	if err != nil {
+		return nil, err
+	}
+	if shifted, err = e.MultPlain(shifted, scale); err != nil {
+		return nil, err
+	}
+	return e.AddConst(shifted, v)
+}
+
+// HEStat is the HEaaN-STAT baseline: Iter Newton iterations from the initial guess 1, without a
+// polynomial approximation. It converges slowly for x much smaller than B.
+type HEStat struct {
+	Iter int
+}
+
+// InvSqrt runs the Newton iteration for 1/√(x/B) from 1 and scales the result by 1/√B.
+func (s HEStat) InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error) {
+	// Step 1: Normalize x/(2B), the Newton iteration converging to 1/√(x/B)
+	normCt, err := scaleFill(e, x, 1.0/(2*B), 0.5)
+	if err != nil {
+		return nil, fmt.Errorf("normalize input: %w", err)
+	}
+
+	// Step 2: Encrypt vector of 1s
+	oneVec := make([]float64, normCt.Size())
+	for i := range oneVec {
+		oneVec[i] = 1.0
+	}
+	ctxtOne, err := e.Encrypt(oneVec, e.Params().MaxLevel())
+	if err != nil {
+		return nil, fmt.Errorf("encrypt one vector: %w", err)
+	}
+
+	// Step 3: Perform Newton's iteration for inverse square root
+	invSqrt, err := e.HENewtonInv(normCt, ctxtOne, B, s.Iter, 2)
+	if err != nil {
+		return nil, fmt.Errorf("HENewtonInv: %w", err)
+	}
+
+	if e.IsBTS {
+		invSqrt, _ = e.DoBootstrap(invSqrt, 1)
+	}
+
+	// Step 4: Final scaling adjustment
+	scaledResult, err := e.MultConst(invSqrt, 1.0/math.Sqrt(B))
+	if err != nil {
+		return nil, fmt.Errorf("final scaling: %w", err)
+	}
+
+	return scaledResult, nil
+}
+
+// InvStd computes 1/√Var[X] with InvSqrt.
+func (s HEStat) InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error) {
+	return invStdOf(e, s, ct, B)
+}
+
+// PPStat is the fixed PP-STAT configuration: a Chebyshev initial guess of degree 2^9 - 2
+// followed by five Newton iterations, at every input level.
+type PPStat struct{}
+
+const (
+	ppstatChebMode       = 2 // ChebyshevInvSqrt mode: 1/√(B·x) on x ∈ (0, 2]
+	ppstatNewtonIter     = 5 // Iteration count for Newton refinement
+	ppstatNewtonScale    = 2 // Scaling for Newton method
+	ppstatBootstrapDepth = 3 // Depth used when bootstrapping initial guess
+)
+
+// InvSqrt evaluates the Chebyshev guess on x/B and refines it on x/2.
+func (PPStat) InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error) {
+	scaled, err := scaleFill(e, x, 1/B, 1)
+	if err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+	half, err := scaleFill(e, x, 0.5, B/2)
+	if err != nil {
+		return nil, fmt.Errorf("halve input: %w", err)
+	}
+	return ppstatInvSqrt(e, scaled, half, B)
+}
+
+// InvStd computes the variance twice, divided by B² for the Chebyshev guess and by 2 for the
+// Newton iteration, each in a single pass over the data.
+func (PPStat) InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error) {
+	denom := float64(ct.Size()) * B
+
+	// Approximate variance
+	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
+	if err != nil {
+		return nil, fmt.Errorf("variance (approx): %w", err)
+	}
+	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
+	if err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (approx): %w", err)
+	}
+
+	// Refined variance
+	denom = float64(ct.Size()) * math.Sqrt(2)
+	varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom*math.Sqrt(2))
+	if err != nil {
+		return nil, fmt.Errorf("compute refined variance: %w", err)
+	}
+	varRefinedCtxt, err := e.selectOneCtxt(varianceRefined)
+	if err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (refined): %w", err)
+	}
+
+	return ppstatInvSqrt(e, varApproxCtxt, varRefinedCtxt, B*B)
+}
+
+// ppstatInvSqrt computes 1/√x from scaled = x/B and half = x/2.
+func ppstatInvSqrt(e *HEEngine, scaled, half *HEData, B float64) (*HEData, error) {
+	var err error
+
+	// Initial guess for 1/√x
+	if e.IsBTS {
+		scaled, err = e.DoBootstrap(scaled, 9)
+		if err != nil {
+			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
+		}
+	}
+	init, err := e.ChebyshevInvSqrt(scaled, ppstatChebMode, B)
+	if err != nil {
+		return nil, fmt.Errorf("ChebyshevInvSqrt: %w", err)
+	}
+	if e.IsBTS {
+		init, err = e.DoBootstrap(init, ppstatBootstrapDepth)
+		if err != nil {
+			return nil, fmt.Errorf("bootstrap: %w", err)
+		}
+	}
+
+	// Newton refinement
+	invSqrt, err := e.HENewtonInv(half, init, B, ppstatNewtonIter, ppstatNewtonScale)
+	if err != nil {
+		return nil, fmt.Errorf("HENewtonInv: %w", err)
+	}
+	return invSqrt, nil
+}
+
+// HEDAP is the optimizer-driven method: the Chebyshev degree, refinement (Newton or Goldschmidt),
+// iteration count and pre-bootstrapping case that the optimizer selected for the input level
+// (see Get_deg_and_iter), under the accuracy ("Basic") or the time ("Fast") constraint. A
+// configuration with pieces runs the Piecewise strategy on [PiecewiseMin, 1] instead.
+//
+// Both methods look the configuration up for the level of the input of the initial guess, as the
+// optimizer does: x/B, one level below x, in InvSqrt, and the variance divided by B²,
+// hedapVarianceDepth levels below ct, in InvStd.
+type HEDAP struct {
+	Fast bool
+}
+
+// InvSqrt evaluates the Chebyshev guess on x/B and refines it on x/2.
+func (s HEDAP) InvSqrt(e *HEEngine, x *HEData, B float64) (*HEData, error) {
+	// Step 1: x/B ∈ (0, 2] for the Chebyshev guess, and x/2 for the refinement
+	scaled, err := scaleFill(e, x, 1/B, 1)
+	if err != nil {
+		return nil, fmt.Errorf("scale input: %w", err)
+	}
+	c, err := getCase(scaled.Level(), s.Fast)
+	if err != nil {
+		return nil, err
+	}
+	deg, iter, cs, refine := c.Degree, c.Iteration, c.Case, c.Refine
+	if deg == 0 {
+		return nil, fmt.Errorf("no inverse square root configuration for level %d", scaled.Level())
+	}
+	if c.Pieces > 0 {
+		return Piecewise{Min: PiecewiseMin, Pieces: c.Pieces, Degree: deg, Iter: iter - 1}.InvSqrt(e, x, B)
+	}
+	if cs == 1 && e.IsBTS {
+		if scaled, err = e.DoBootstrap(scaled, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
+		}
+	}
+	half, err := e.MultConst(scaled, B/2)
+	if err != nil {
+		return nil, fmt.Errorf("halve input: %w", err)
+	}
+
+	// Step 2: Chebyshev guess and refinement
+	return hedapInvSqrt(e, half, scaled, B, deg, iter-1, refine)
+}
+
+// hedapVarianceDepth is the number of levels consumed by the variance in HEDAP.InvStd.
+const hedapVarianceDepth = 2
+
+// InvStd computes the variance divided by B² in a single pass over the data, and selects the
+// configuration for the level of that variance.
+func (s HEDAP) InvStd(e *HEEngine, ct *HEData, B float64) (*HEData, error) {
+	c, err := getCase(ct.Level()-hedapVarianceDepth, s.Fast)
+	if err != nil {
+		return nil, err
+	}
+	deg, iter, cs, refine := c.Degree, c.Iteration, c.Case, c.Refine
+	if c.Pieces > 0 {
+		return Piecewise{Min: PiecewiseMin, Pieces: c.Pieces, Degree: deg, Iter: iter - 1}.InvStd(e, ct, B)
+	}
+
+	denom := float64(ct.Size()) * B
+	varianceApprox, err := varianceWithCustomDenom(e, ct, denom, denom*B)
+	if err != nil {
+		return nil, fmt.Errorf("variance (approx): %w", err)
+	}
+	varApproxCtxt, err := e.selectOneCtxt(varianceApprox)
+	if err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (approx): %w", err)
+	}
+
+	var varRefinedCtxt *HEData
+	if cs == 1 {
+		if e.IsBTS {
+			varApproxCtxt, err = e.DoBootstrap(varApproxCtxt, e.params.MaxLevel())
+			if err != nil {
+				return nil, fmt.Errorf("bootstrap (invSqrt init): %w", err)
+			}
+		}
+		varRefinedCtxt, err = e.MultConst(varApproxCtxt, (B*B)/2)
+		if err != nil {
+			return nil, fmt.Errorf("variance (refined): %w", err)
+		}
+	} else {
+		denom := float64(ct.Size()) * math.Sqrt(2)
+		varianceRefined, err := varianceWithCustomDenom(e, ct, denom, denom*math.Sqrt(2))
+		if err != nil {
+			return nil, fmt.Errorf("variance (refined): %w", err)
+		}
+		varRefinedCtxt, err = e.selectOneCtxt(varianceRefined)
+		if err != nil {
+			return nil, fmt.Errorf("selectOneCtxt (refined): %w", err)
+		}
+	}
+
+	// Chebyshev guess and refinement
+	return hedapInvSqrt(e, varRefinedCtxt, varApproxCtxt, B*B, deg, iter-1, refine)
+}
+
+// hedapInvSqrt computes 1/√x from half = x/2 and scaled = x/B with CryptoInvSqrt, or with
+// CryptoGoldschmidt or HENewtonInvFused when the optimizer selected another refinement.
+func hedapInvSqrt(e *HEEngine, half, scaled *HEData, B float64, deg, iter int, refine InvSqrtRefine) (*HEData, error) {
+	const newtonScale = 2
+
+	switch refine {
+	case NewtonRefine:
+		invSqrt, err := e.CryptoInvSqrt(half, scaled, B, deg, iter, 2, newtonScale, ChebyshevInit)
+		if err != nil {
+			return nil, fmt.Errorf("CryptoInvSqrt: %w", err)
+		}
+		return invSqrt, nil
+	case GoldschmidtRefine:
+		_, invSqrt, err := e.CryptoGoldschmidt(half, scaled, B, deg, iter, 2, 2, ChebyshevInit)
+		if err != nil {
+			return nil, fmt.Errorf("CryptoGoldschmidt: %w", err)
+		}
+		return invSqrt, nil
+	case FusedNewtonRefine:
+		init, err := e.invSqrtInitGuess(scaled, 2, B, deg, ChebyshevInit)
+		if err != nil {
+			return nil, err
+		}
+		invSqrt, err := e.HENewtonInvFused(half, init, B, iter, newtonScale)
+		if err != nil {
+			return nil, fmt.Errorf("HENewtonInvFused: %w", err)
+		}
+		return invSqrt, nil
+	default:
+		return nil, fmt.Errorf("invalid InvSqrt refinement: %d", refine)
+	}
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+// invSqrtWant returns 1/√x for every entry of x.
+func invSqrtWant(x []float64) []float64 {
+	want := make([]float64, len(x))
+	for i := range want {
+		want[i] = 1 / math.Sqrt(x[i])
+	}
+	return want
+}
+
+// checkStrategy compares InvSqrt on x and InvStd on data with their plaintext values.
+func checkStrategy(t *testing.T, e *HEEngine, name string, s InvSqrtStrategy, x, data []float64, B, tol float64) {
+	t.Helper()
+	ct, err := e.Encrypt(x, e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	invSqrt, err := s.InvSqrt(e, ct, B)
+	checkClose(t, name+" 1/√x", decryptTest(t, e, invSqrt, err), invSqrtWant(x), tol)
+
+	if ct, err = e.Encrypt(data, e.Params().MaxLevel()); err != nil {
+		t.Fatal(err)
+	}
+	invStd, err := s.InvStd(e, ct, B)
+	checkScalar(t, name+" 1/σ", decryptTest(t, e, invStd, err), 1/utils.StdDev(data), tol)
+}
+
+func TestStrategies(t *testing.T) {
+	e := testEngine(t)
+	x := utils.Linspace(4, 20, 50)
+	data := normalData(1, 300, 2, 3)
+
+	// HEStat starts from 1 and needs inputs close to B
+	checkStrategy(t, e, "HEStat", HEStat{Iter: 10}, utils.Linspace(8, 20, 50), normalData(2, 300, 2, 12), 10, 1e-4)
+	checkStrategy(t, e, "PPStat", PPStat{}, x, data, 10, 1e-4)
+	checkStrategy(t, e, "InvSqrtFunc", InvSqrtFunc(PPStat{}.InvSqrt), x, data, 10, 1e-4)
+}
+
+func TestHEDAP(t *testing.T) {
+	e := testBTSEngine(t)
+	x := utils.Linspace(0.5, 20, 64)
+	data := normalData(1, 300, 2, 3)
+
+	checkStrategy(t, e, "HEDAP", HEDAP{}, x, data, 10, 1e-4)
+	checkStrategy(t, e, "HEDAP (Fast)", HEDAP{Fast: true}, x, data, 10, 1e-3)
+
+	// Below the top level the guess is bootstrapped, which requires in-domain unused slots
+	ct, err := e.Encrypt(x, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invSqrt, err := HEDAP{}.InvSqrt(e, ct, 10)
+	checkClose(t, "HEDAP 1/√x (level 7)", decryptTest(t, e, invSqrt, err), invSqrtWant(x), 1e-4)
+}
+
+func TestHEDAPMissingProfile(t *testing.T) {
+	profile := OptimizerProfile
+	OptimizerProfile = "missing.json"
+	t.Cleanup(func() { OptimizerProfile = profile })
+
+	if _, _, _, _, err := Get_deg_and_iter(10, false); err == nil {
+		t.Error("Get_deg_and_iter: expected an error for a missing profile")
+	}
+	e := testEngine(t)
+	ct, err := e.Encrypt(utils.Linspace(1, 4, 16), e.Params().MaxLevel())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (HEDAP{}).InvSqrt(e, ct, 10); err == nil {
+		t.Error("HEDAP: expected an error for a missing profile")
+	}
+}
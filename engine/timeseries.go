@@ -0,0 +1,421 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// RollingMean returns the mean of the trailing window x[i-w+1..i] at every position i of the
+// series ct. As with the NaN of incomplete windows in pandas, the first w-1 outputs are zero.
+//
+// The window sums are built by doubling (see windowSum) in about log2(w)+1 levels, with the
+// division by w folded into the final mask.
+func (e *HEEngine) RollingMean(ct *HEData, w int) (*HEData, error) {
+	if err := e.checkWindow(ct, w); err != nil {
+		return nil, err
+	}
+	sum, err := e.windowSum(ct, w)
+	if err != nil {
+		return nil, fmt.Errorf("window sum: %w", err)
+	}
+	return e.MultPlain(sum, windowMask(ct.Size(), w, 1/float64(w)))
+}
+
+// RollingVariance returns the population variance of the trailing window x[i-w+1..i] at every
+// position i of the series ct, with the first w-1 outputs set to zero as in RollingMean.
+//
+// The series is centered on its global mean first, since the variance is computed as
+// E_w[x²] - E_w[x]², which loses precision when the mean is large relative to the spread.
+func (e *HEEngine) RollingVariance(ct *HEData, w int) (*HEData, error) {
+	if err := e.checkWindow(ct, w); err != nil {
+		return nil, err
+	}
+	_, _, variance, err := e.rollingMoments(ct, w)
+	return variance, err
+}
+
+// RollingZScore returns (x_i - μ_i)/σ_i at every position i of the series ct, where μ_i and σ_i²
+// are the RollingMean and RollingVariance of the trailing window, with the first w-1 outputs set
+// to zero. 1/σ_i comes from the strategy's InvSqrt, so B bounds every window variance to 2B²,
+// and windows of almost constant values lose accuracy.
+func (e *HEEngine) RollingZScore(ct *HEData, w int, B float64, s InvSqrtStrategy) (*HEData, error) {
+	if err := e.checkWindow(ct, w); err != nil {
+		return nil, err
+	}
+	if w < 2 {
+		return nil, fmt.Errorf("z-score needs a window of at least 2 values, got %d", w)
+	}
+
+	// Step 1: Centered series, window means and variances
+	centered, mean, variance, err := e.rollingMoments(ct, w)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: x_i - μ_i, the window mean being zero on the incomplete windows
+	dev, err := e.Sub(centered, mean)
+	if err != nil {
+		return nil, fmt.Errorf("deviation: %w", err)
+	}
+
+	// Step 3: 1/σ_i, with the zero variances of the incomplete windows and of the unused slots
+	// replaced by B², inside the domain of InvSqrt
+	if e.IsBTS {
+		if variance, err = e.DoBootstrap(variance, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (window variance): %w", err)
+		}
+	}
+	if variance, err = e.fillIncomplete(variance, w, B*B); err != nil {
+		return nil, fmt.Errorf("fill incomplete windows: %w", err)
+	}
+	invStd, err := s.InvSqrt(e, variance, B*B)
+	if err != nil {
+		return nil, fmt.Errorf("invSqrt (window variance): %w", err)
+	}
+
+	// Step 4: (x_i - μ_i) × 1/σ_i on the complete windows
+	if e.IsBTS {
+		if invStd, err = e.DoBootstrap(invStd, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σ): %w", err)
+		}
+		if dev, err = e.DoBootstrap(dev, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (deviation): %w", err)
+		}
+	}
+	z, err := e.Mult(dev, invStd)
+	if err != nil {
+		return nil, fmt.Errorf("z-score: %w", err)
+	}
+	return e.MultPlain(z, windowMask(ct.Size(), w, 1))
+}
+
+// ewmaNegligible is the weight (1-α)^k below which EWMA stops the scan: older values no longer
+// contribute above the CKKS noise.
+const ewmaNegligible = 1e-12
+
+// EWMA returns the exponentially weighted moving average s_i = α·x_i + (1-α)·s_{i-1}, s_0 = x_0,
+// of the series ct (pandas' ewm(alpha=α, adjust=False)).
+//
+// Unrolled, s_i = Σ_{j≤i} α(1-α)^(i-j)·x_j + (1-α)^(i+1)·x_0. The sum is a linear recurrence,
+// evaluated as a Hillis–Steele scan y_i ← y_i + (1-α)^k·y_{i-k} for k = 1, 2, 4, ..., one level
+// per step, until k reaches n or (1-α)^k becomes negligible: 7 steps for α = 0.2, 12 for
+// α = 0.01. The x_0 term is added with a plaintext vector of powers of 1-α. Since the EWMA
+// commutes with shifts, the series is centered on its global mean, which keeps the magnitudes
+// small where the scan is bootstrapped.
+func (e *HEEngine) EWMA(ct *HEData, alpha float64) (*HEData, error) {
+	if !(alpha > 0 && alpha <= 1) {
+		return nil, fmt.Errorf("invalid smoothing factor: %g", alpha)
+	}
+	n := ct.Size()
+	beta := 1 - alpha
+
+	// Step 1: Center on the global mean, which Mean leaves at zero in the unused slots
+	mean, err := e.Mean(ct)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	centered, err := e.Sub(ct, mean)
+	if err != nil {
+		return nil, fmt.Errorf("center series: %w", err)
+	}
+
+	// Step 2: Scan of α·x
+	y, err := e.MultConst(centered, alpha)
+	if err != nil {
+		return nil, fmt.Errorf("scale series: %w", err)
+	}
+	for k := 1; k < n && math.Pow(beta, float64(k)) > ewmaNegligible; k *= 2 {
+		if e.IsBTS {
+			if y, err = e.DoBootstrap(y, 2); err != nil {
+				return nil, fmt.Errorf("bootstrap (scan step %d): %w", k, err)
+			}
+		}
+		shifted, err := e.delay(y, k, math.Pow(beta, float64(k)))
+		if err != nil {
+			return nil, fmt.Errorf("scan step %d: %w", k, err)
+		}
+		if y, err = e.Add(y, shifted); err != nil {
+			return nil, fmt.Errorf("scan step %d: %w", k, err)
+		}
+	}
+
+	// Step 3: x_0 in every slot, weighted by (1-α)^(i+1)
+	if beta > 0 {
+		x0, err := e.MultPlain(centered, []float64{1})
+		if err != nil {
+			return nil, fmt.Errorf("select x_0: %w", err)
+		}
+		if x0, err = e.Sum(x0); err != nil {
+			return nil, fmt.Errorf("replicate x_0: %w", err)
+		}
+		powers := make([]float64, n)
+		for i := range powers {
+			powers[i] = math.Pow(beta, float64(i+1))
+		}
+		if x0, err = e.MultPlain(x0, powers); err != nil {
+			return nil, fmt.Errorf("weight x_0: %w", err)
+		}
+		if y, err = e.Add(y, x0); err != nil {
+			return nil, fmt.Errorf("add x_0 term: %w", err)
+		}
+	}
+
+	// Step 4: Shift back by the mean
+	return e.Add(y, mean)
+}
+
+// AutoCovariance returns the autocovariances c_k = (1/n)·Σ_{t≥k} (x_t - μ)(x_{t-k} - μ) of the
+// series ct for the lags k = 1..maxLag, packed so that slot k-1 holds c_k. Each lag costs one
+// delay of the centered series (see delay) and an inner product.
+func (e *HEEngine) AutoCovariance(ct *HEData, maxLag int) (*HEData, error) {
+	n := ct.Size()
+	if maxLag < 1 || maxLag >= n || maxLag > e.params.MaxSlots() {
+		return nil, fmt.Errorf("invalid maximum lag %d for %d values", maxLag, n)
+	}
+
+	// Step 1: Center on the global mean, which Mean leaves at zero in the unused slots
+	mean, err := e.Mean(ct)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	centered, err := e.Sub(ct, mean)
+	if err != nil {
+		return nil, fmt.Errorf("center series: %w", err)
+	}
+
+	// Step 2: c_k = <x - μ, delay(x - μ, k)/n>, moved into slot k-1
+	var packed *HEData
+	for k := 1; k <= maxLag; k++ {
+		lagged, err := e.delay(centered, k, 1/float64(n))
+		if err != nil {
+			return nil, fmt.Errorf("delay by %d: %w", k, err)
+		}
+		cov, err := e.InnerProduct(centered, lagged)
+		if err != nil {
+			return nil, fmt.Errorf("lag %d: %w", k, err)
+		}
+		slot := make([]float64, k)
+		slot[k-1] = 1
+		if cov, err = e.MultPlain(cov, slot); err != nil {
+			return nil, fmt.Errorf("pack lag %d: %w", k, err)
+		}
+		if packed == nil {
+			packed = cov
+		} else if packed, err = e.Add(packed, cov); err != nil {
+			return nil, fmt.Errorf("pack lag %d: %w", k, err)
+		}
+	}
+
+	return NewHEData(packed.Ciphertexts()[:1], maxLag, packed.Level(), ct.Scale()), nil
+}
+
+// Autocorrelation returns the autocorrelation function r_k = c_k/c_0 of the series ct for the
+// lags k = 1..maxLag, packed so that slot k-1 holds r_k, with c_k from AutoCovariance. The
+// inverse variance 1/c_0 = (1/σ)² is computed once with the strategy, so B bounds the data as
+// in ZScoreNorm.
+func (e *HEEngine) Autocorrelation(ct *HEData, maxLag int, B float64, s InvSqrtStrategy) (*HEData, error) {
+	// Step 1: Packed autocovariances
+	cov, err := e.AutoCovariance(ct, maxLag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: 1/σ²
+	invStd, err := s.InvStd(e, ct, B)
+	if err != nil {
+		return nil, fmt.Errorf("InvStd: %w", err)
+	}
+	if e.IsBTS {
+		if invStd, err = e.DoBootstrap(invStd, 2); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/σ): %w", err)
+		}
+		if cov, err = e.DoBootstrap(cov, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (autocovariances): %w", err)
+		}
+	}
+	invVar, err := e.Mult(invStd, invStd)
+	if err != nil {
+		return nil, fmt.Errorf("1/σ²: %w", err)
+	}
+
+	// Step 3: r_k = c_k × 1/σ²
+	return e.Mult(cov, invVar)
+}
+
+// checkWindow validates a window of w values for the series ct.
+func (e *HEEngine) checkWindow(ct *HEData, w int) error {
+	if w < 1 || w > ct.Size() || w > e.params.MaxSlots() {
+		return fmt.Errorf("invalid window %d for %d values", w, ct.Size())
+	}
+	return nil
+}
+
+// rollingMoments returns the series centered on its global mean, and the window means and
+// population variances of the centered series on the complete windows.
+func (e *HEEngine) rollingMoments(ct *HEData, w int) (centered, mean, variance *HEData, err error) {
+	// Step 1: Center on the global mean, which Mean leaves at zero in the unused slots
+	global, err := e.Mean(ct)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("mean: %w", err)
+	}
+	if centered, err = e.Sub(ct, global); err != nil {
+		return nil, nil, nil, fmt.Errorf("center series: %w", err)
+	}
+	sq, err := e.Mult(centered, centered)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("square series: %w", err)
+	}
+
+	// Step 2: E_w[x] and E_w[x²]
+	mask := windowMask(ct.Size(), w, 1/float64(w))
+	if mean, err = e.windowSum(centered, w); err != nil {
+		return nil, nil, nil, fmt.Errorf("window sum: %w", err)
+	}
+	if mean, err = e.MultPlain(mean, mask); err != nil {
+		return nil, nil, nil, fmt.Errorf("window mean: %w", err)
+	}
+	meanOfSq, err := e.windowSum(sq, w)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("window sum of squares: %w", err)
+	}
+	if meanOfSq, err = e.MultPlain(meanOfSq, mask); err != nil {
+		return nil, nil, nil, fmt.Errorf("window mean of squares: %w", err)
+	}
+
+	// Step 3: E_w[x²] - E_w[x]²
+	if e.IsBTS {
+		if mean, err = e.DoBootstrap(mean, 2); err != nil {
+			return nil, nil, nil, fmt.Errorf("bootstrap (window mean): %w", err)
+		}
+	}
+	meanSq, err := e.Mult(mean, mean)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("squared window mean: %w", err)
+	}
+	if variance, err = e.Sub(meanOfSq, meanSq); err != nil {
+		return nil, nil, nil, fmt.Errorf("window variance: %w", err)
+	}
+	return centered, mean, variance, nil
+}
+
+// windowSum returns Σ_{j<w} x_{i-j} at every position i of the series ct, with the values before
+// the start of the series taken as zero. As in sumShifted, the delays are combined by doubling,
+// so that only O(log w) of them are evaluated.
+func (e *HEEngine) windowSum(ct *HEData, w int) (*HEData, error) {
+	var result *HEData
+	power, span, offset := ct, 1, 0
+
+	for count := w; count > 0; {
+		var err error
+		if count&1 == 1 {
+			shifted := power
+			if offset > 0 {
+				if e.IsBTS {
+					if power, err = e.DoBootstrap(power, 2); err != nil {
+						return nil, err
+					}
+				}
+				if shifted, err = e.delay(power, offset, 1); err != nil {
+					return nil, err
+				}
+			}
+			if result == nil {
+				result = shifted
+			} else if result, err = e.Add(result, shifted); err != nil {
+				return nil, err
+			}
+			offset += span
+		}
+
+		count >>= 1
+		if count > 0 {
+			if e.IsBTS {
+				if power, err = e.DoBootstrap(power, 2); err != nil {
+					return nil, err
+				}
+			}
+			doubled, err := e.delay(power, span, 1)
+			if err != nil {
+				return nil, err
+			}
+			if power, err = e.Add(power, doubled); err != nil {
+				return nil, err
+			}
+			span *= 2
+		}
+	}
+
+	return result, nil
+}
+
+// delay returns weight·x_{i-k} at every position i of the series ct, and zero for i < k. Values
+// move across ciphertext boundaries: ciphertext c takes the rotated ciphertexts c-q and c-q-1,
+// with q = ⌊k/Slots⌋, under complementary plaintext masks. It consumes one level.
+func (e *HEEngine) delay(ct *HEData, k int, weight float64) (*HEData, error) {
+	slots := e.params.MaxSlots()
+	num := len(ct.Ciphertexts())
+	q, r := k/slots, k%slots
+
+	// Step 1: Rotate every ciphertext right by r
+	rotated, err := e.Rotate(ct, -r)
+	if err != nil {
+		return nil, fmt.Errorf("rotate by %d: %w", r, err)
+	}
+
+	// Step 2: Ciphertext c-q fills slots r.., ciphertext c-q-1 slots ..r; the masks zero the
+	// sources before the start of the series
+	src := rotated.Ciphertexts()
+	cur := make([]*rlwe.Ciphertext, num)
+	prev := make([]*rlwe.Ciphertext, num)
+	curMask := make([]float64, num*slots)
+	prevMask := make([]float64, num*slots)
+	for c := range num {
+		cur[c], prev[c] = src[max(c-q, 0)], src[max(c-q-1, 0)]
+		for j := range slots {
+			if j >= r && c-q >= 0 {
+				curMask[c*slots+j] = weight
+			}
+			if j < r && c-q-1 >= 0 {
+				prevMask[c*slots+j] = weight
+			}
+		}
+	}
+
+	out, err := e.MultPlain(NewHEData(cur, ct.Size(), rotated.Level(), ct.Scale()), curMask)
+	if err != nil {
+		return nil, fmt.Errorf("mask current ciphertexts: %w", err)
+	}
+	if r == 0 || num <= q+1 {
+		return out, nil
+	}
+	carried, err := e.MultPlain(NewHEData(prev, ct.Size(), rotated.Level(), ct.Scale()), prevMask)
+	if err != nil {
+		return nil, fmt.Errorf("mask previous ciphertexts: %w", err)
+	}
+	return e.Add(out, carried)
+}
+
+// fillIncomplete returns ct with the positions 0..w-2 of the incomplete windows and the unused
+// slots set to v. It consumes one level.
+func (e *HEEngine) fillIncomplete(ct *HEData, w int, v float64) (*HEData, error) {
+	shifted, err := e.SubConst(ct, v)
+	if err != nil {
+		return nil, err
+	}
+	if shifted, err = e.MultPlain(shifted, windowMask(ct.Size(), w, 1)); err != nil {
+		return nil, err
+	}
+	return e.AddConst(shifted, v)
+}
+
+// windowMask returns the plaintext vector with value on the positions w-1..n-1 of the complete
+// windows of a series of n values.
+func windowMask(n, w int, value float64) []float64 {
+	mask := make([]float64, n)
+	for i := w - 1; i < n; i++ {
+		mask[i] = value
+	}
+	return mask
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestRolling(t *testing.T) {
+	e := testEngine(t)
+	// Two ciphertexts, so that the windows cross a ciphertext boundary
+	x := normalData(1, 600, 5, 2)
+	ct := encryptTest(t, e, x)
+
+	mean, err := e.RollingMean(ct, 7)
+	checkClose(t, "RollingMean", decryptTest(t, e, mean, err), utils.RollingMean(x, 7), 1e-6)
+
+	variance, err := e.RollingVariance(ct, 7)
+	checkClose(t, "RollingVariance", decryptTest(t, e, variance, err), utils.RollingVariance(x, 7), 1e-6)
+
+	z, err := e.RollingZScore(ct, 7, 4, PPStat{})
+	checkClose(t, "RollingZScore", decryptTest(t, e, z, err), utils.RollingZScore(x, 7), 1e-3)
+
+	ewma, err := e.EWMA(ct, 0.2)
+	checkClose(t, "EWMA", decryptTest(t, e, ewma, err), utils.EWMA(x, 0.2), 1e-6)
+}
+
+func TestRollingZScoreBTS(t *testing.T) {
+	e := testBTSEngine(t)
+	x := normalData(2, 100, 5, 2)
+
+	// HEDAP bootstraps its guess, so every slot of the window variances must be in its domain
+	z, err := e.RollingZScore(encryptTest(t, e, x), 5, 4, HEDAP{})
+	checkClose(t, "RollingZScore", decryptTest(t, e, z, err), utils.RollingZScore(x, 5), 1e-3)
+}
+
+func TestAutocorrelation(t *testing.T) {
+	e := testEngine(t)
+	// An AR(1) series, so that the autocorrelations decay from 0.7
+	noise := normalData(3, 300, 0, 1)
+	x := make([]float64, len(noise))
+	for i := range x {
+		x[i] = 2 + noise[i]
+		if i > 0 {
+			x[i] += 0.7 * (x[i-1] - 2)
+		}
+	}
+
+	acf, err := e.Autocorrelation(encryptTest(t, e, x), 5, 5, PPStat{})
+	checkClose(t, "Autocorrelation", decryptTest(t, e, acf, err), utils.Autocorrelation(x, 5), 1e-4)
+}
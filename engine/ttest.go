@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// ttestDFPrecision is the relative error targeted by the inverse in the Welch degrees of freedom.
+const ttestDFPrecision = 1e-6
+
+// TTestResult holds an encrypted t statistic and its degrees of freedom, both in the first slot.
+type TTestResult struct {
+	Statistic *HEData
+	DF        *HEData
+}
+
+// TTest computes the two-sample t statistic of a against b.
+//
+// With welch == false this is Student's test with the pooled sample variance, and DF holds the
+// public value na+nb-2. With welch == true the standard error is √(sa²/na + sb²/nb) and DF is the
+// Welch–Satterthwaite approximation (sa²/na + sb²/nb)² / Σ (s²/n)²/(n-1). Its reciprocal, in
+// terms of the weights w = (s²/n)/(sa²/na + sb²/nb), always lies in [1/(na+nb-2), 1/(min(na,nb)-1)],
+// so it is inverted with Inverse on that public interval.
+//
+// B bounds the data as in InvSqrtStrategy.InvStd: both population variances must be at most 2B².
+// utils.PValueT turns the decrypted statistic and degrees of freedom into a p-value.
+func (e *HEEngine) TTest(a, b *HEData, B float64, welch bool, s InvSqrtStrategy) (*TTestResult, error) {
+	na, nb := float64(a.Size()), float64(b.Size())
+	if na < 2 || nb < 2 {
+		return nil, fmt.Errorf("t-test needs at least two values per group, got %d and %d", a.Size(), b.Size())
+	}
+
+	// Step 1: Means and population variances of both groups
+	diff, varA, varB, err := e.ttestMoments(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2: Squared standard error of the difference, as c_a·varA + c_b·varB
+	var ca, cb float64
+	if welch {
+		ca, cb = 1/(na-1), 1/(nb-1)
+	} else {
+		k := (1/na + 1/nb) / (na + nb - 2)
+		ca, cb = na*k, nb*k
+	}
+	termA, err := e.MultConst(varA, ca)
+	if err != nil {
+		return nil, fmt.Errorf("scale variance of a: %w", err)
+	}
+	termB, err := e.MultConst(varB, cb)
+	if err != nil {
+		return nil, fmt.Errorf("scale variance of b: %w", err)
+	}
+	se2, err := e.Add(termA, termB)
+	if err != nil {
+		return nil, fmt.Errorf("squared standard error: %w", err)
+	}
+
+	// Step 3: t = (μa - μb) × (1/SE)
+	invSE, err := s.InvSqrt(e, se2, (ca+cb)*B*B)
+	if err != nil {
+		return nil, fmt.Errorf("invSqrt (standard error): %w", err)
+	}
+	if e.IsBTS {
+		if invSE, err = e.DoBootstrap(invSE, e.params.MaxLevel()); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/SE): %w", err)
+		}
+	}
+	t, err := e.Mult(diff, invSE)
+	if err != nil {
+		return nil, fmt.Errorf("t statistic: %w", err)
+	}
+
+	// Step 4: Degrees of freedom
+	if !welch {
+		df, err := e.Encrypt([]float64{na + nb - 2}, t.Level())
+		if err != nil {
+			return nil, fmt.Errorf("encrypt degrees of freedom: %w", err)
+		}
+		return &TTestResult{Statistic: t, DF: df}, nil
+	}
+
+	// 1/df = wa²/(na-1) + wb²/(nb-1), with w = c·var·(1/SE)²
+	if e.IsBTS {
+		if termA, err = e.DoBootstrap(termA, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (variance of a): %w", err)
+		}
+		if termB, err = e.DoBootstrap(termB, 3); err != nil {
+			return nil, fmt.Errorf("bootstrap (variance of b): %w", err)
+		}
+	}
+	invSE2, err := e.Mult(invSE, invSE)
+	if err != nil {
+		return nil, fmt.Errorf("1/SE²: %w", err)
+	}
+	var invDF *HEData
+	for _, term := range []struct {
+		v *HEData
+		n float64
+	}{{termA, na}, {termB, nb}} {
+		w, err := e.Mult(term.v, invSE2)
+		if err != nil {
+			return nil, fmt.Errorf("weight: %w", err)
+		}
+		w2, err := e.Mult(w, w)
+		if err != nil {
+			return nil, fmt.Errorf("squared weight: %w", err)
+		}
+		if w2, err = e.MultConst(w2, 1/(term.n-1)); err != nil {
+			return nil, fmt.Errorf("scale squared weight: %w", err)
+		}
+		if invDF == nil {
+			invDF = w2
+		} else if invDF, err = e.Add(invDF, w2); err != nil {
+			return nil, fmt.Errorf("1/df: %w", err)
+		}
+	}
+	df, err := e.Inverse(invDF, 1/(na+nb-2), 1/(min(na, nb)-1), ttestDFPrecision)
+	if err != nil {
+		return nil, fmt.Errorf("degrees of freedom: %w", err)
+	}
+
+	return &TTestResult{Statistic: t, DF: df}, nil
+}
+
+// TTestOneSample computes the one-sample t statistic (μ - mu0)·√n/s of ct against the plaintext
+// mean mu0, where s² is the sample variance. DF holds the public value n-1, and B is as in TTest.
+func (e *HEEngine) TTestOneSample(ct *HEData, mu0, B float64, s InvSqrtStrategy) (*TTestResult, error) {
+	n := float64(ct.Size())
+	if n < 2 {
+		return nil, fmt.Errorf("t-test needs at least two values, got %d", ct.Size())
+	}
+
+	// Step 1: Mean and population variance
+	mean, err := e.Mean(ct)
+	if err != nil {
+		return nil, fmt.Errorf("mean: %w", err)
+	}
+	variance, err := e.Variance(ct)
+	if err != nil {
+		return nil, fmt.Errorf("variance: %w", err)
+	}
+	if variance, err = e.selectOneCtxt(variance); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (variance): %w", err)
+	}
+
+	// Step 2: s²/n = var/(n-1)
+	se2, err := e.MultConst(variance, 1/(n-1))
+	if err != nil {
+		return nil, fmt.Errorf("squared standard error: %w", err)
+	}
+	invSE, err := s.InvSqrt(e, se2, B*B/(n-1))
+	if err != nil {
+		return nil, fmt.Errorf("invSqrt (standard error): %w", err)
+	}
+	if e.IsBTS {
+		if invSE, err = e.DoBootstrap(invSE, 1); err != nil {
+			return nil, fmt.Errorf("bootstrap (1/SE): %w", err)
+		}
+	}
+
+	// Step 3: t = (μ - mu0) × (1/SE)
+	diff, err := e.SubConst(mean, mu0)
+	if err != nil {
+		return nil, fmt.Errorf("μ - mu0: %w", err)
+	}
+	if diff, err = e.selectOneCtxt(diff); err != nil {
+		return nil, fmt.Errorf("selectOneCtxt (μ - mu0): %w", err)
+	}
+	t, err := e.Mult(diff, invSE)
+	if err != nil {
+		return nil, fmt.Errorf("t statistic: %w", err)
+	}
+
+	df, err := e.Encrypt([]float64{n - 1}, t.Level())
+	if err != nil {
+		return nil, fmt.Errorf("encrypt degrees of freedom: %w", err)
+	}
+	return &TTestResult{Statistic: t, DF: df}, nil
+}
+
+// ttestMoments returns μa - μb and the population variances of a and b, each in one ciphertext.
+func (e *HEEngine) ttestMoments(a, b *HEData) (diff, varA, varB *HEData, err error) {
+	meanA, err := e.Mean(a)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("mean of a: %w", err)
+	}
+	meanB, err := e.Mean(b)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("mean of b: %w", err)
+	}
+	if meanA, err = e.selectOneCtxt(meanA); err != nil {
+		return nil, nil, nil, fmt.Errorf("selectOneCtxt (mean of a): %w", err)
+	}
+	if meanB, err = e.selectOneCtxt(meanB); err != nil {
+		return nil, nil, nil, fmt.Errorf("selectOneCtxt (mean of b): %w", err)
+	}
+	if diff, err = e.Sub(meanA, meanB); err != nil {
+		return nil, nil, nil, fmt.Errorf("μa - μb: %w", err)
+	}
+
+	if varA, err = e.Variance(a); err != nil {
+		return nil, nil, nil, fmt.Errorf("variance of a: %w", err)
+	}
+	if varB, err = e.Variance(b); err != nil {
+		return nil, nil, nil, fmt.Errorf("variance of b: %w", err)
+	}
+	if varA, err = e.selectOneCtxt(varA); err != nil {
+		return nil, nil, nil, fmt.Errorf("selectOneCtxt (variance of a): %w", err)
+	}
+	if varB, err = e.selectOneCtxt(varB); err != nil {
+		return nil, nil, nil, fmt.Errorf("selectOneCtxt (variance of b): %w", err)
+	}
+	return diff, varA, varB, nil
+}
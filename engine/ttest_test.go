@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/hm-choi/pp-stat-plus/utils"
+)
+
+func TestTTest(t *testing.T) {
+	e := testEngine(t)
+	a := normalData(1, 300, 5, 2)
+	b := normalData(2, 200, 4.5, 3)
+	ctA, ctB := encryptTest(t, e, a), encryptTest(t, e, b)
+
+	for _, welch := range []bool{false, true} {
+		res, err := e.TTest(ctA, ctB, 10, welch, PPStat{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stat, df := utils.TTest(a, b, welch)
+		checkScalar(t, "t", decryptTest(t, e, res.Statistic, nil), stat, 1e-4)
+		checkScalar(t, "df", decryptTest(t, e, res.DF, nil), df, 1e-4)
+	}
+}
+
+func TestTTestOneSample(t *testing.T) {
+	e := testEngine(t)
+	x := normalData(3, 400, 1.2, 1)
+
+	res, err := e.TTestOneSample(encryptTest(t, e, x), 1, 10, PPStat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat, df := utils.TTestOneSample(x, 1)
+	checkScalar(t, "t", decryptTest(t, e, res.Statistic, nil), stat, 1e-4)
+	checkScalar(t, "df", decryptTest(t, e, res.DF, nil), df, 1e-4)
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/hm-choi/pp-stat-plus/config"
 	"github.com/hm-choi/pp-stat-plus/engine"
-	invsqrt "github.com/hm-choi/pp-stat-plus/examples/inv_sqrt"
 	"github.com/hm-choi/pp-stat-plus/utils"
 )
 
@@ -76,7 +75,7 @@ func main() {
 				log.Println("-------------------------------------------------------------------")
 
 				start := time.Now()
-				heStat, _ := invsqrt.HEStat(e, ct, 21, B)
+				heStat, _ := engine.HEStat{Iter: 21}.InvSqrt(e, ct_base, B)
 				elapsed := time.Since(start)
 				hsResult, _ := e.Decrypt(heStat)
 				_, hsMRE := utils.CheckMRE(hsResult, hsResult, invS, ct.Size())
@@ -90,7 +89,7 @@ func main() {
 			log.Println("-------------------------------------------------------------------")
 
 			start := time.Now()
-			ppStat, _ := e.CryptoInvSqrt(ct, scaled_ct, B, 9.0, 6, 1, 2)
+			ppStat, _ := e.CryptoInvSqrt(ct, scaled_ct, B, 510, 6, 1, 2, engine.ChebyshevInit)
 			elapsed := time.Since(start)
 			psResult, _ := e.Decrypt(ppStat)
 			_, psMRE := utils.CheckMRE(psResult, psResult, invS, ct.Size())
@@ -105,7 +104,10 @@ func main() {
 
 			log.Println("<Basic>")
 
-			deg, iter, cs := engine.Get_deg_and_iter(ct_base.Level() - scaling_depth, false)
+			deg, iter, cs, _, err := engine.Get_deg_and_iter(ct_base.Level() - scaling_depth, false)
+			if err != nil {
+				log.Fatal(err)
+			}
 
 			start = time.Now()
 			if cs == 1 {
@@ -116,7 +118,7 @@ func main() {
 				scaled_ct, _ = e.MultConst(ct_temp, 2.0/B)
 				ct, _ 		 = e.MultConst(ct_temp, 1.0/2)
 			}
-			cryptoInvSqrt, _ := e.CryptoInvSqrt(ct, scaled_ct, B, deg, iter, 1, 2)
+			cryptoInvSqrt, _ := e.CryptoInvSqrt(ct, scaled_ct, B, deg, iter, 1, 2, engine.ChebyshevInit)
 			elapsed = time.Since(start)
 			cisResult, _ := e.Decrypt(cryptoInvSqrt)
 			_, cisMRE := utils.CheckMRE(cisResult, cisResult, invS, ct.Size())
@@ -125,7 +127,10 @@ func main() {
 
 			log.Println("<Fast>")
 
-			deg, iter, cs = engine.Get_deg_and_iter(ct_base.Level() - scaling_depth, true)
+			deg, iter, cs, _, err = engine.Get_deg_and_iter(ct_base.Level() - scaling_depth, true)
+			if err != nil {
+				log.Fatal(err)
+			}
 
 			start = time.Now()
 			if cs == 1 {
@@ -136,7 +141,7 @@ func main() {
 				scaled_ct, _ = e.MultConst(ct_temp, 2.0/B)
 				ct, _ 		 = e.MultConst(ct_temp, 1.0/2)
 			}
-			cryptoInvSqrt, _ = e.CryptoInvSqrt(ct, scaled_ct, B, deg, iter, 1, 2)
+			cryptoInvSqrt, _ = e.CryptoInvSqrt(ct, scaled_ct, B, deg, iter, 1, 2, engine.ChebyshevInit)
 			elapsed = time.Since(start)
 			cisResult, _ = e.Decrypt(cryptoInvSqrt)
 			_, cisMRE = utils.CheckMRE(cisResult, cisResult, invS, ct.Size())
@@ -77,7 +77,7 @@ func main() {
 			log.Println("[Z-Score Normalization]")
 			start := time.Now()
 			zNormReal := utils.ZScoreNorm(values3)
-			zNorm, _ := e.ZScoreNorm(ctxt3, RANGE2, fast)
+			zNorm, _ := e.ZScoreNorm(ctxt3, RANGE2, engine.HEDAP{Fast: fast})
 			duration := time.Since(start)
 			zNormResult, _ := e.Decrypt(zNorm)
 			_, mre := utils.CheckMRE(zNormResult, zNormResult, zNormReal, len(zNormReal))
@@ -87,7 +87,7 @@ func main() {
 
 			start = time.Now()
 			zNormReal = utils.ZScoreNorm(values3)
-			zNorm, _ = e.ZScoreNorm_ppstat(ctxt3, RANGE2)
+			zNorm, _ = e.ZScoreNorm(ctxt3, RANGE2, engine.PPStat{})
 			duration = time.Since(start)
 			zNormResult, _ = e.Decrypt(zNorm)
 			_, mre = utils.CheckMRE(zNormResult, zNormResult, zNormReal, len(zNormReal))
@@ -99,7 +99,7 @@ func main() {
 			log.Println("[Skewness]")
 			_, _, skewReal := utils.Skewness(values1)
 			start = time.Now()
-			skew, _ := e.Skewness(ctxt1, RANGE, fast)
+			skew, _ := e.Skewness(ctxt1, RANGE, engine.HEDAP{Fast: fast})
 			duration = time.Since(start)
 			skewResult, _ := e.Decrypt(skew)
 			mre = math.Abs(skewResult[0]-skewReal) / math.Abs(skewReal)
@@ -109,7 +109,7 @@ func main() {
 
 			_, _, skewReal = utils.Skewness(values1)
 			start = time.Now()
-			skew, _ = e.Skewness_ppstat(ctxt1, RANGE)
+			skew, _ = e.Skewness(ctxt1, RANGE, engine.PPStat{})
 			duration = time.Since(start)
 			skewResult, _ = e.Decrypt(skew)
 			mre = math.Abs(skewResult[0]-skewReal) / math.Abs(skewReal)
@@ -121,7 +121,7 @@ func main() {
 			log.Println("[Kurtosis]")
 			_, _, kurtReal := utils.Kurtosis(values1)
 			start = time.Now()
-			kurt, _ := e.Kurtosis(ctxt1, RANGE, fast)
+			kurt, _ := e.Kurtosis(ctxt1, RANGE, engine.HEDAP{Fast: fast})
 			duration = time.Since(start)
 			kurtResult, _ := e.Decrypt(kurt)
 			mre = math.Abs(kurtResult[0]-kurtReal) / math.Abs(kurtReal)
@@ -131,7 +131,7 @@ func main() {
 
 			_, _, kurtReal = utils.Kurtosis(values1)
 			start = time.Now()
-			kurt, _ = e.Kurtosis_ppstat(ctxt1, RANGE)
+			kurt, _ = e.Kurtosis(ctxt1, RANGE, engine.PPStat{})
 			duration = time.Since(start)
 			kurtResult, _ = e.Decrypt(kurt)
 			mre = math.Abs(kurtResult[0]-kurtReal) / math.Abs(kurtReal)
@@ -143,7 +143,7 @@ func main() {
 			log.Println("[Correlation]")
 			_, corrReal, _ := utils.Correlation(values1, values2)
 			start = time.Now()
-			corr, _ := e.PCorrCoeff(ctxt1, ctxt2, RANGE, fast)
+			corr, _ := e.PCorrCoeff(ctxt1, ctxt2, RANGE, engine.HEDAP{Fast: fast})
 			duration = time.Since(start)
 			corrResult, _ := e.Decrypt(corr)
 			mre = math.Abs(corrResult[0]-corrReal) / math.Abs(corrReal)
@@ -153,7 +153,7 @@ func main() {
 
 			_, corrReal, _ = utils.Correlation(values1, values2)
 			start = time.Now()
-			corr, _ = e.PCorrCoeff_ppstat(ctxt1, ctxt2, RANGE)
+			corr, _ = e.PCorrCoeff(ctxt1, ctxt2, RANGE, engine.PPStat{})
 			duration = time.Since(start)
 			corrResult, _ = e.Decrypt(corr)
 			mre = math.Abs(corrResult[0]-corrReal) / math.Abs(corrReal)
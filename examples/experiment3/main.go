@@ -13,6 +13,7 @@ import (
 )
 
 func main() {
+	hedap, ppstat := engine.HEDAP{}, engine.PPStat{}
 	engine := engine.NewHEEngine(config.NewParameters(16, 11, 50, true))
 	EVAL_NUM, B := 10, 50.0
 	ageSlice, _ := utils.ReadCSV("../../examples/dataset/adult_dataset.csv", 0)
@@ -76,19 +77,19 @@ func main() {
 		fmt.Println("[============ Age Test ============]")
 
 		TIME := time.Now()
-		zScoreNorm1, _ := engine.ZScoreNorm(age, B, false)
+		zScoreNorm1, _ := engine.ZScoreNorm(age, B, hedap)
 		AGE_ZNORM_TIME := time.Since(TIME)
 		zSNAge, _ := engine.Decrypt(zScoreNorm1)
 		fmt.Println("Age ZNorm", zSNAge[0:1], utils.ZScoreNorm(ageSlice)[:1], AGE_ZNORM_TIME)
 
 		TIME = time.Now()
-		zScoreNorm_ppstat1, _ := engine.ZScoreNorm_ppstat(age, B)
+		zScoreNorm_ppstat1, _ := engine.ZScoreNorm(age, B, ppstat)
 		AGE_ZNORM_PPSTAT_TIME := time.Since(TIME)
 		zSNAge_ppstat, _ := engine.Decrypt(zScoreNorm_ppstat1)
 
 
 		TIME = time.Now()
-		skew1, _ := engine.Skewness(age, B, false)
+		skew1, _ := engine.Skewness(age, B, hedap)
 		AGE_SKEW_TIME := time.Since(TIME)
 		skewAge, _ := engine.Decrypt(skew1)
 		AgeSkew_MRE[i] = math.Abs(skewAge[0]-skew_age) / math.Abs(skew_age)
@@ -97,7 +98,7 @@ func main() {
 		fmt.Println("Age skewResult", skewAge[0], math.Abs(skewAge[0]-skew_age), math.Abs(skewAge[0]-skew_age)/math.Abs(skew_age), AGE_SKEW_TIME)
 
 		TIME = time.Now()
-		skew_ppstat1, _ := engine.Skewness_ppstat(age, B)
+		skew_ppstat1, _ := engine.Skewness(age, B, ppstat)
 		AGE_SKEW_PPSTAT_TIME := time.Since(TIME)
 		skewAge_ppstat, _ := engine.Decrypt(skew_ppstat1)
 		AgeSkew_MRE_PPSTAT[i] = math.Abs(skewAge_ppstat[0]-skew_age) / math.Abs(skew_age)
@@ -106,7 +107,7 @@ func main() {
 
 
 		TIME = time.Now()
-		kurt1, _ := engine.Kurtosis(age, B, false)
+		kurt1, _ := engine.Kurtosis(age, B, hedap)
 		AGE_KURT_TIME := time.Since(TIME)
 		kurtAge, _ := engine.Decrypt(kurt1)
 		AgeKurt_MRE[i] = math.Abs(kurtAge[0]-kurt_age) / math.Abs(kurt_age)
@@ -115,7 +116,7 @@ func main() {
 		fmt.Println("Age kurtResult", kurtAge[0], math.Abs(kurtAge[0]-kurt_age), math.Abs(kurtAge[0]-kurt_age)/math.Abs(kurt_age), AGE_KURT_TIME)
 
 		TIME = time.Now()
-		kurt_ppstat1, _ := engine.Kurtosis_ppstat(age, B)
+		kurt_ppstat1, _ := engine.Kurtosis(age, B, ppstat)
 		AGE_KURT_PPSTAT_TIME := time.Since(TIME)
 		kurtAge_ppstat, _ := engine.Decrypt(kurt_ppstat1)
 		AgeKurt_MRE_PPSTAT[i] = math.Abs(kurtAge_ppstat[0]-kurt_age) / math.Abs(kurt_age)
@@ -126,19 +127,19 @@ func main() {
 		fmt.Println("[============ HPW Test ============]")
 
 		TIME = time.Now()
-		zScoreNorm2, _ := engine.ZScoreNorm(hpw, 100.0, false)
+		zScoreNorm2, _ := engine.ZScoreNorm(hpw, 100.0, hedap)
 		HPW_ZNORM_TIME := time.Since(TIME)
 		zSNHpw, _ := engine.Decrypt(zScoreNorm2)
 		fmt.Println("HPW ZNorm", zSNHpw[0:1], utils.ZScoreNorm(zSNHpw)[:1], HPW_ZNORM_TIME)
 
 		TIME = time.Now()
-		zScoreNorm_ppstat2, _ := engine.ZScoreNorm_ppstat(hpw, 100.0)
+		zScoreNorm_ppstat2, _ := engine.ZScoreNorm(hpw, 100.0, ppstat)
 		HPW_ZNORM_PPSTAT_TIME := time.Since(TIME)
 		zSNHpw_ppstat, _ := engine.Decrypt(zScoreNorm_ppstat2)
 
 
 		TIME = time.Now()
-		skew2, _ := engine.Skewness(hpw, B, false)
+		skew2, _ := engine.Skewness(hpw, B, hedap)
 		HPW_SKEW_TIME := time.Since(TIME)
 		skewHpw, _ := engine.Decrypt(skew2)
 		HPWSkew_MRE[i] = math.Abs(skewHpw[0]-skew_hpw) / math.Abs(skew_hpw)
@@ -147,7 +148,7 @@ func main() {
 		fmt.Println("HPW skewResult", skewHpw[0], math.Abs(skewHpw[0]-skew_hpw), math.Abs(skewHpw[0]-skew_hpw)/math.Abs(skew_hpw), HPW_SKEW_TIME)
 
 		TIME = time.Now()
-		skew_ppstat2, _ := engine.Skewness(hpw, B, false)
+		skew_ppstat2, _ := engine.Skewness(hpw, B, hedap)
 		HPW_SKEW_PPSTAT_TIME := time.Since(TIME)
 		skewHpw_ppstat, _ := engine.Decrypt(skew_ppstat2)
 		HPWSkew_MRE_PPSTAT[i] = math.Abs(skewHpw_ppstat[0]-skew_hpw) / math.Abs(skew_hpw)
@@ -156,7 +157,7 @@ func main() {
 
 
 		TIME = time.Now()
-		kurt2, _ := engine.Kurtosis(hpw, B, false)
+		kurt2, _ := engine.Kurtosis(hpw, B, hedap)
 		HPW_KURT_TIME := time.Since(TIME)
 		kurtHpw, _ := engine.Decrypt(kurt2)
 		HPWKurt_MRE[i] = math.Abs(kurtHpw[0]-kurt_hpw) / math.Abs(kurt_hpw)
@@ -165,7 +166,7 @@ func main() {
 		fmt.Println("HPW kurtResult", kurtHpw[0], math.Abs(kurtHpw[0]-kurt_hpw), math.Abs(kurtHpw[0]-kurt_hpw)/math.Abs(kurt_hpw), HPW_KURT_TIME)
 
 		TIME = time.Now()
-		kurt_ppstat2, _ := engine.Kurtosis_ppstat(hpw, B)
+		kurt_ppstat2, _ := engine.Kurtosis(hpw, B, ppstat)
 		HPW_KURT_PPSTAT_TIME := time.Since(TIME)
 		kurtHpw_ppstat, _ := engine.Decrypt(kurt_ppstat2)
 		HPWKurt_MRE_PPSTAT[i] = math.Abs(kurtHpw_ppstat[0]-kurt_hpw) / math.Abs(kurt_hpw)
@@ -176,19 +177,19 @@ func main() {
 		fmt.Println("[============ Edu Test ============]")
 
 		TIME = time.Now()
-		zScoreNorm3, _ := engine.ZScoreNorm(edu, 100.0, false)
+		zScoreNorm3, _ := engine.ZScoreNorm(edu, 100.0, hedap)
 		EDU_ZNORM_TIME := time.Since(TIME)
 		zSNEdu, _ := engine.Decrypt(zScoreNorm3)
 		fmt.Println("Edu ZNorm", zSNEdu[0:1], utils.ZScoreNorm(zSNEdu)[:1], EDU_ZNORM_TIME)
 
 		TIME = time.Now()
-		zScoreNorm_ppstat3, _ := engine.ZScoreNorm_ppstat(edu, 100.0)
+		zScoreNorm_ppstat3, _ := engine.ZScoreNorm(edu, 100.0, ppstat)
 		EDU_ZNORM_PPSTAT_TIME := time.Since(TIME)
 		zSNEdu_ppstat, _ := engine.Decrypt(zScoreNorm_ppstat3)
 
 
 		TIME = time.Now()
-		skew3, _ := engine.Skewness(edu, B, false)
+		skew3, _ := engine.Skewness(edu, B, hedap)
 		EDU_SKEW_TIME := time.Since(TIME)
 		skewEdu, _ := engine.Decrypt(skew3)
 		EduSkew_MRE[i] = math.Abs(skewEdu[0]-skew_edu) / math.Abs(skew_edu)
@@ -197,7 +198,7 @@ func main() {
 		fmt.Println("Edu skewResult", skewEdu[0], math.Abs(skewEdu[0]-skew_edu), math.Abs(skewEdu[0]-skew_edu)/math.Abs(skew_edu), EDU_SKEW_TIME)
 
 		TIME = time.Now()
-		skew_ppstat3, _ := engine.Skewness_ppstat(edu, B)
+		skew_ppstat3, _ := engine.Skewness(edu, B, ppstat)
 		EDU_SKEW_PPSTAT_TIME := time.Since(TIME)
 		skewEdu_ppstat, _ := engine.Decrypt(skew_ppstat3)
 		EduSkew_MRE_PPSTAT[i] = math.Abs(skewEdu_ppstat[0]-skew_edu) / math.Abs(skew_edu)
@@ -206,7 +207,7 @@ func main() {
 
 
 		TIME = time.Now()
-		kurt3, _ := engine.Kurtosis(edu, B, false)
+		kurt3, _ := engine.Kurtosis(edu, B, hedap)
 		EDU_KURT_TIME := time.Since(TIME)
 		kurtEdu, _ := engine.Decrypt(kurt3)
 		EduKurt_MRE[i] = math.Abs(kurtEdu[0]-kurt_edu) / math.Abs(kurt_edu)
@@ -215,7 +216,7 @@ func main() {
 		fmt.Println("Edu kurtResult", kurtEdu[0], math.Abs(kurtEdu[0]-kurt_edu), math.Abs(kurtEdu[0]-kurt_edu)/math.Abs(kurt_edu), EDU_KURT_TIME)
 
 		TIME = time.Now()
-		kurt_ppstat3, _ := engine.Kurtosis_ppstat(edu, B)
+		kurt_ppstat3, _ := engine.Kurtosis(edu, B, ppstat)
 		EDU_KURT_PPSTAT_TIME := time.Since(TIME)
 		kurtEdu_ppstat, _ := engine.Decrypt(kurt_ppstat3)
 		EduKurt_MRE_PPSTAT[i] = math.Abs(kurtEdu_ppstat[0]-kurt_edu) / math.Abs(kurt_edu)
@@ -226,7 +227,7 @@ func main() {
 		fmt.Println("[============ Corr Test ============]")
 		_, corrr, _ := utils.Correlation(ageSlice, hpwSlice)
 		TIME = time.Now()
-		corr, _ := engine.PCorrCoeff(age, hpw, B, false)
+		corr, _ := engine.PCorrCoeff(age, hpw, B, hedap)
 		CORR_TIME := time.Since(TIME)
 		corrtResult, _ := engine.Decrypt(corr)
 		AgeHPW_CORR_MRE[i] = math.Abs(corrtResult[0]-corrr) / math.Abs(corrr)
@@ -235,7 +236,7 @@ func main() {
 		fmt.Println("corrtResult(AGE vs HPW)", corrtResult[0], corrr, math.Abs(corrtResult[0]-corrr), math.Abs(corrtResult[0]-corrr)/math.Abs(corrr), CORR_TIME)
 
 		TIME = time.Now()
-		corr_ppstat, _ := engine.PCorrCoeff_ppstat(age, hpw, B)
+		corr_ppstat, _ := engine.PCorrCoeff(age, hpw, B, ppstat)
 		CORR_PPSTAT_TIME := time.Since(TIME)
 		corrtResult_ppstat, _ := engine.Decrypt(corr_ppstat)
 		AgeHPW_CORR_MRE_PPSTAT[i] = math.Abs(corrtResult_ppstat[0]-corrr) / math.Abs(corrr)
@@ -244,7 +245,7 @@ func main() {
 
 		_, corrr, _ = utils.Correlation(ageSlice, eduSlice)
 		TIME = time.Now()
-		corr, _ = engine.PCorrCoeff(age, edu, B, false)
+		corr, _ = engine.PCorrCoeff(age, edu, B, hedap)
 		CORR_TIME = time.Since(TIME)
 		corrtResult, _ = engine.Decrypt(corr)
 		AGE_EDU_CORR_MRE[i] = math.Abs(corrtResult[0]-corrr) / math.Abs(corrr)
@@ -253,7 +254,7 @@ func main() {
 		fmt.Println("corrtResult(AGE vs EDU)", corrtResult[0], corrr, math.Abs(corrtResult[0]-corrr), math.Abs(corrtResult[0]-corrr)/math.Abs(corrr), CORR_TIME)
 
 		TIME = time.Now()
-		corr_ppstat, _ = engine.PCorrCoeff_ppstat(age, edu, B)
+		corr_ppstat, _ = engine.PCorrCoeff(age, edu, B, ppstat)
 		CORR_PPSTAT_TIME = time.Since(TIME)
 		corrtResult_ppstat, _ = engine.Decrypt(corr_ppstat)
 		AGE_EDU_CORR_MRE_PPSTAT[i] = math.Abs(corrtResult_ppstat[0]-corrr) / math.Abs(corrr)
@@ -13,6 +13,7 @@ import (
 )
 
 func main() {
+	hedap, hedapFast, ppstat := engine.HEDAP{}, engine.HEDAP{Fast: true}, engine.PPStat{}
 	engine := engine.NewHEEngine(config.NewParameters(16, 11, 50, true))
 	ageSlice, _ := utils.ReadCSV("../../examples/dataset/insurance.csv", 0)
 	bmiSlice, _ := utils.ReadCSV("../../examples/dataset/insurance.csv", 2)
@@ -63,7 +64,7 @@ func main() {
 
 	for i := 0; i < int(EVAL_NUM); i++ {
 		TIME := time.Now()
-		zNormCharge, _ := engine.ZScoreNorm(charge, 100.0, false)
+		zNormCharge, _ := engine.ZScoreNorm(charge, 100.0, hedap)
 		CHARGE_ZNORM_TIME := time.Since(TIME)
 		zSNcharge, _ := engine.Decrypt(zNormCharge)
 
@@ -76,7 +77,7 @@ func main() {
 		fmt.Println("Charge ZNorm <Basic>", zScoreMaeCharge, zScoreMreCharge, CHARGE_ZNORM_TIME)
 
 		TIME = time.Now()
-		zNormCharge, _ = engine.ZScoreNorm(charge, 100.0, true)
+		zNormCharge, _ = engine.ZScoreNorm(charge, 100.0, hedapFast)
 		CHARGE_ZNORM_TIME = time.Since(TIME)
 		zSNcharge, _ = engine.Decrypt(zNormCharge)
 
@@ -89,7 +90,7 @@ func main() {
 		fmt.Println("Charge ZNorm <Fast>", zScoreMaeCharge, zScoreMreCharge, CHARGE_ZNORM_TIME)
 
 		TIME = time.Now()
-		zNormCharge_ppstat, _ := engine.ZScoreNorm_ppstat(charge, 100.0)
+		zNormCharge_ppstat, _ := engine.ZScoreNorm(charge, 100.0, ppstat)
 		CHARGE_ZNORM__PPSTAT_TIME := time.Since(TIME)
 		zSNcharge_ppstat, _ := engine.Decrypt(zNormCharge_ppstat)
 
@@ -102,7 +103,7 @@ func main() {
 
 
 		TIME = time.Now()
-		skewCharge, _ := engine.Skewness(charge, B, false)
+		skewCharge, _ := engine.Skewness(charge, B, hedap)
 		CHARGE_SKEW_TIME := time.Since(TIME)
 		skCharge, _ := engine.Decrypt(skewCharge)
 		CG_SKEW_MRE0[i] = math.Abs(skCharge[0]-skew_cg) / math.Abs(skew_cg)
@@ -111,7 +112,7 @@ func main() {
 		fmt.Println("Charge skewResult <Basic>", skCharge[0], math.Abs(skCharge[0]-skew_cg), math.Abs(skCharge[0]-skew_cg)/math.Abs(skew_cg), CHARGE_SKEW_TIME)
 
 		TIME = time.Now()
-		skewCharge, _ = engine.Skewness(charge, B, true)
+		skewCharge, _ = engine.Skewness(charge, B, hedapFast)
 		CHARGE_SKEW_TIME = time.Since(TIME)
 		skCharge, _ = engine.Decrypt(skewCharge)
 		CG_SKEW_MRE1[i] = math.Abs(skCharge[0]-skew_cg) / math.Abs(skew_cg)
@@ -120,7 +121,7 @@ func main() {
 		fmt.Println("Charge skewResult <Fast>", skCharge[0], math.Abs(skCharge[0]-skew_cg), math.Abs(skCharge[0]-skew_cg)/math.Abs(skew_cg), CHARGE_SKEW_TIME)
 
 		TIME = time.Now()
-		skewCharge_ppstat, _ := engine.Skewness_ppstat(charge, B)
+		skewCharge_ppstat, _ := engine.Skewness(charge, B, ppstat)
 		CHARGE_SKEW_PPSTAT_TIME := time.Since(TIME)
 		skCharge_ppstat, _ := engine.Decrypt(skewCharge_ppstat)
 		CG_SKEW_MRE_PPSTAT[i] = math.Abs(skCharge_ppstat[0]-skew_cg) / math.Abs(skew_cg)
@@ -129,7 +130,7 @@ func main() {
 
 
 		TIME = time.Now()
-		kurtCharge, _ := engine.Kurtosis(charge, B, false)
+		kurtCharge, _ := engine.Kurtosis(charge, B, hedap)
 		CHARGE_KURT_TIME := time.Since(TIME)
 		ktCharge, _ := engine.Decrypt(kurtCharge)
 		CG_KURT_MRE0[i] = math.Abs(ktCharge[0]-kurt_cg) / math.Abs(kurt_cg)
@@ -138,7 +139,7 @@ func main() {
 		fmt.Println("BCharge kurtResult <Basic>", ktCharge[0], math.Abs(ktCharge[0]-kurt_cg), math.Abs(ktCharge[0]-kurt_cg)/math.Abs(kurt_cg), CHARGE_KURT_TIME)
 
 		TIME = time.Now()
-		kurtCharge, _ = engine.Kurtosis(charge, B, true)
+		kurtCharge, _ = engine.Kurtosis(charge, B, hedapFast)
 		CHARGE_KURT_TIME = time.Since(TIME)
 		ktCharge, _ = engine.Decrypt(kurtCharge)
 		CG_KURT_MRE1[i] = math.Abs(ktCharge[0]-kurt_cg) / math.Abs(kurt_cg)
@@ -147,7 +148,7 @@ func main() {
 		fmt.Println("BCharge kurtResult <Fast>", ktCharge[0], math.Abs(ktCharge[0]-kurt_cg), math.Abs(ktCharge[0]-kurt_cg)/math.Abs(kurt_cg), CHARGE_KURT_TIME)
 
 		TIME = time.Now()
-		kurtCharge_ppstat, _ := engine.Kurtosis(charge, B, false)
+		kurtCharge_ppstat, _ := engine.Kurtosis(charge, B, hedap)
 		CHARGE_KURT_PPSTAT_TIME := time.Since(TIME)
 		ktCharge_ppstat, _ := engine.Decrypt(kurtCharge_ppstat)
 		CG_KURT_MRE_PPSTAT[i] = math.Abs(ktCharge_ppstat[0]-kurt_cg) / math.Abs(kurt_cg)
@@ -157,7 +158,7 @@ func main() {
 
 		_, corrr1, _ := utils.Correlation(ageSlice, chargeSlice)
 		TIME = time.Now()
-		corr, _ := engine.PCorrCoeff(age, charge, B, false)
+		corr, _ := engine.PCorrCoeff(age, charge, B, hedap)
 		AGE_CG_TIME := time.Since(TIME)
 		corrResult, _ := engine.Decrypt(corr)
 		AGE_CG_CORR_MAE0[i] = math.Abs(corrResult[0] - corrr1)
@@ -166,7 +167,7 @@ func main() {
 		fmt.Println("Correlation (BMI, CHARGE) <Basic>", corrResult[0], math.Abs(corrResult[0]-corrr1), math.Abs(corrResult[0]-corrr1)/corrr1)
 
 		TIME = time.Now()
-		corr, _ = engine.PCorrCoeff(age, charge, B, true)
+		corr, _ = engine.PCorrCoeff(age, charge, B, hedapFast)
 		AGE_CG_TIME = time.Since(TIME)
 		corrResult, _ = engine.Decrypt(corr)
 		AGE_CG_CORR_MAE1[i] = math.Abs(corrResult[0] - corrr1)
@@ -175,7 +176,7 @@ func main() {
 		fmt.Println("Correlation (BMI, CHARGE) <Fast>", corrResult[0], math.Abs(corrResult[0]-corrr1), math.Abs(corrResult[0]-corrr1)/corrr1)
 
 		TIME = time.Now()
-		corr_ppstat, _ := engine.PCorrCoeff(age, charge, B, false)
+		corr_ppstat, _ := engine.PCorrCoeff(age, charge, B, hedap)
 		AGE_CG_PPSTAT_TIME := time.Since(TIME)
 		corrResult_ppstat, _ := engine.Decrypt(corr_ppstat)
 		AGE_CG_CORR_MAE_PPSTAT[i] = math.Abs(corrResult_ppstat[0] - corrr1)
@@ -185,7 +186,7 @@ func main() {
 
 		_, corrr2, _ := utils.Correlation(bmiSlice, chargeSlice)
 		TIME = time.Now()
-		corr2, _ := engine.PCorrCoeff(bmi, charge, B, false)
+		corr2, _ := engine.PCorrCoeff(bmi, charge, B, hedap)
 		BMI_CG_TIME := time.Since(TIME)
 		corrResult2, _ := engine.Decrypt(corr2)
 		BMI_CG_CORR_MAE0[i] = math.Abs(corrResult2[0] - corrr2)
@@ -194,7 +195,7 @@ func main() {
 		fmt.Println("Correlation (BMI, CHARGE) <Basic>", corrResult2[0], math.Abs(corrResult2[0]-corrr2), math.Abs(corrResult2[0]-corrr2)/corrr2)
 
 		TIME = time.Now()
-		corr2, _ = engine.PCorrCoeff(bmi, charge, B, false)
+		corr2, _ = engine.PCorrCoeff(bmi, charge, B, hedap)
 		BMI_CG_TIME = time.Since(TIME)
 		corrResult2, _ = engine.Decrypt(corr2)
 		BMI_CG_CORR_MAE1[i] = math.Abs(corrResult2[0] - corrr2)
@@ -203,7 +204,7 @@ func main() {
 		fmt.Println("Correlation (BMI, CHARGE) <Fast>", corrResult2[0], math.Abs(corrResult2[0]-corrr2), math.Abs(corrResult2[0]-corrr2)/corrr2)
 
 		TIME = time.Now()
-		corr2_ppstat, _ := engine.PCorrCoeff(bmi, charge, B, false)
+		corr2_ppstat, _ := engine.PCorrCoeff(bmi, charge, B, hedap)
 		BMI_CG_PPSTAT_TIME := time.Since(TIME)
 		corrResult2_ppstat, _ := engine.Decrypt(corr2_ppstat)
 		BMI_CG_CORR_MAE_PPSTAT[i] = math.Abs(corrResult2_ppstat[0] - corrr2)
@@ -213,7 +214,7 @@ func main() {
 
 		_, corrr3, _ := utils.Correlation(smokerSlice, chargeSlice)
 		TIME = time.Now()
-		corr3, _ := engine.PCorrCoeff(smoker, charge, B, false)
+		corr3, _ := engine.PCorrCoeff(smoker, charge, B, hedap)
 		SMOKER_CG_TIME := time.Since(TIME)
 		corrResult3, _ := engine.Decrypt(corr3)
 		SMOKER_CG_CORR_MAE0[i] = math.Abs(corrResult3[0] - corrr3)
@@ -222,7 +223,7 @@ func main() {
 		fmt.Println("Correlation (BMI, CHARGE) <Basic>", corrResult3[0], math.Abs(corrResult3[0]-corrr3), math.Abs(corrResult3[0]-corrr3)/corrr3, SMOKER_CG_TIME)
 
 		TIME = time.Now()
-		corr3, _ = engine.PCorrCoeff(smoker, charge, B, false)
+		corr3, _ = engine.PCorrCoeff(smoker, charge, B, hedap)
 		SMOKER_CG_TIME = time.Since(TIME)
 		corrResult3, _ = engine.Decrypt(corr3)
 		SMOKER_CG_CORR_MAE1[i] = math.Abs(corrResult3[0] - corrr3)
@@ -231,7 +232,7 @@ func main() {
 		fmt.Println("Correlation (BMI, CHARGE) <Fast>", corrResult3[0], math.Abs(corrResult3[0]-corrr3), math.Abs(corrResult3[0]-corrr3)/corrr3, SMOKER_CG_TIME)
 
 		TIME = time.Now()
-		corr3_ppstat, _ := engine.PCorrCoeff(smoker, charge, B, false)
+		corr3_ppstat, _ := engine.PCorrCoeff(smoker, charge, B, hedap)
 		SMOKER_CG_PPSTAT_TIME := time.Since(TIME)
 		corrResult3_ppstat, _ := engine.Decrypt(corr3_ppstat)
 		SMOKER_CG_CORR_MAE_PPSTAT[i] = math.Abs(corrResult3_ppstat[0] - corrr3)
@@ -3,63 +3,46 @@ package optimizer
 import (
 	"fmt"
 	"log"
-	"math"
 	"time"
 
 	"github.com/hm-choi/pp-stat-plus/engine"
 	"github.com/hm-choi/pp-stat-plus/utils"
 	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
 )
 
 
-func ChebyshevInvSqrt_deg_log(e *engine.HEEngine, ct *engine.HEData, mode int, B float64, deg float64) (*engine.HEData, error) {
+func InvSqrtInit_deg_log(e *engine.HEEngine, ct *engine.HEData, mode int, B float64, degree int, init engine.InvSqrtInit) (*engine.HEData, error) {
 	
 	cpData := ct.CopyData()
-	if cpData.Level() - int(deg) < 0 {
+	if cpData.Level() - engine.PolyDepth(degree) < 0 {
 		if e.IsBTS {
 			cpData, _ = e.DoBootstrap(cpData, e.Params().MaxLevel())
 		}
 	}
 	
-	d := deg
-
-	var F func(float64) float64
-
-	switch mode {
-	case 1:
-		F = func(x float64) (y float64) {
-			if x > -1.0 {
-				return 1 / math.Sqrt(B/2) / (math.Sqrt(x + 1.0))
-			} else {
-				return 0
-			}
-		}
-	case 2:
-		F = func(x float64) (y float64) {
-			if x > -1.0 {
-				return 1 / math.Sqrt(B) / (math.Sqrt(x + 1.0))
-			} else {
-				return 0
-			}
-		}
-	case 0:
-		F = func(x float64) float64 {
-			if x > -1.0 {
-				return 1 / math.Sqrt(x+1.0)
-			}
-			return 0
+	var gcbsp bignum.Polynomial
+	var err error
+	switch init {
+	case engine.ChebyshevInit:
+		gcbsp, err = e.InvSqrtPoly(mode, B, degree+1)
+	case engine.RemezInit:
+		var bound float64
+		if gcbsp, bound, err = e.RemezInvSqrtPoly(mode, B, degree, engine.RemezInvSqrtMin); err == nil {
+			log.Println("Remez relative error bound", bound)
 		}
 	default:
-		return nil, fmt.Errorf("invalid InvSqrt mode: %d", mode)
-
+		err = fmt.Errorf("invalid InvSqrt initial guess: %d", init)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	scaled_ct, err := e.SubConst(cpData, 1)
 	if err != nil {
 		return nil, err
 	}
-	gcbsp := engine.GetChebyshevPoly(1.0, int(math.Pow(2, float64(d))-2), F)
 	poly := polynomial.NewPolynomial(gcbsp)
 	polyEval := polynomial.NewEvaluator(e.Params(), e.Evaluator())
 
@@ -126,15 +109,18 @@ func HENewtonInv_log(e *engine.HEEngine, ct, init *engine.HEData, B float64, ite
 	return nil
 }
 
-func CryptoInvSqrt_log(e *engine.HEEngine, ct *engine.HEData, scaled_ct *engine.HEData, B float64, deg float64, i_max int, inv_ans []float64, start time.Time) (error) {
+func CryptoInvSqrt_log(e *engine.HEEngine, ct *engine.HEData, scaled_ct *engine.HEData, B float64, degree int, i_max int, inv_ans []float64, start time.Time, init engine.InvSqrtInit) (error) {
 
-	if scaled_ct.Level() - int(deg) < 0 {
+	if scaled_ct.Level() - engine.PolyDepth(degree) < 0 {
 		if e.IsBTS {
 			scaled_ct, _ = e.DoBootstrap(scaled_ct, e.Params().MaxLevel())
 		}
 	}
 
-	y, _ := ChebyshevInvSqrt_deg_log(e, scaled_ct, 1, B, deg)
+	y, err := InvSqrtInit_deg_log(e, scaled_ct, 1, B, degree, init)
+	if err != nil {
+		return err
+	}
 
 
 	if e.IsBTS {
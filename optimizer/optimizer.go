@@ -11,41 +11,102 @@ import (
 )
 
 type Dtuple struct {
-	D float64
+	D int
 	C, I int
 	M, T float64
+	R engine.InvSqrtRefine
+	P int
 }
 
 type Rtuple struct {
-	D float64
+	D int
 	C, I int
 	M, T float64
+	R engine.InvSqrtRefine
+	P int
 }
 
-func GetOptIter(e *engine.HEEngine, ct *engine.HEData, scaled_ct *engine.HEData, ans []float64, deg, B float64, i_max int, delta float64) (int, float64, float64) {
+func GetOptIter(e *engine.HEEngine, ct *engine.HEData, scaled_ct *engine.HEData, ans []float64, degree int, B float64, i_max int, delta float64, init engine.InvSqrtInit, refine engine.InvSqrtRefine) (int, float64, float64) {
 
-	M, T := make([]float64, i_max), make([]float64, i_max)
+	start := time.Now()
+
+	y, err := InvSqrtInit_deg_log(e, scaled_ct, 1, B, degree, init)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return refineOptIter(e, ct, y, ans, i_max, delta, refine, start)
+}
+
+// GetPiecewiseIter is GetOptIter with the piecewise guess of p on ct_base, with B/2 as the
+// piecewise bound, refined by Newton iterations on ct = ct_base/2.
+func GetPiecewiseIter(e *engine.HEEngine, ct *engine.HEData, ct_base *engine.HEData, ans []float64, p engine.Piecewise, B float64, i_max int, delta float64) (int, float64, float64) {
 
 	start := time.Now()
 
-	y, _ := ChebyshevInvSqrt_deg_log(e, scaled_ct, 1, B, deg)
+	y, err := e.PiecewiseInvSqrtInit(ct_base, B/2, p)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return refineOptIter(e, ct, y, ans, i_max, delta, engine.NewtonRefine, start)
+}
+
+// refineOptIter runs i_max iterations of refine from y ≈ 1/√(2x) for x in ct, and returns the
+// first iteration count whose MRE is within delta of the best one, with its MRE and time.
+func refineOptIter(e *engine.HEEngine, ct *engine.HEData, y *engine.HEData, ans []float64, i_max int, delta float64, refine engine.InvSqrtRefine, start time.Time) (int, float64, float64) {
+
+	M, T := make([]float64, i_max), make([]float64, i_max)
 
 	N := 2
 	x:= ct.CopyData()
 
-	for i := range i_max {
-		
+	// Goldschmidt: g = x·y, h = y, with x holding half of the input
+	var g *engine.HEData
+	if refine == engine.GoldschmidtRefine {
 		if e.IsBTS {
+			x, _ = e.DoBootstrap(x, 5)
 			y, _ = e.DoBootstrap(y, 4)
-		}	
+		}
+		g, _ = e.Mult(x, y)
+	}
 
-		tmp_a_c, _ := e.MultConst(y, float64((N+1))/float64(N))
-		tmp_b_c, _ := e.Mult(x, y)
+	for i := range i_max {
+		
+		switch refine {
+		case engine.NewtonRefine:
+			if e.IsBTS {
+				y, _ = e.DoBootstrap(y, 4)
+			}
+
+			tmp_a_c, _ := e.MultConst(y, float64((N+1))/float64(N))
+			tmp_b_c, _ := e.Mult(x, y)
 
-		y, _ = e.Mult(y, y)
+			y, _ = e.Mult(y, y)
+
+			tmp_b_c, _ = e.Mult(tmp_b_c, y)
+			y, _ = e.Sub(tmp_a_c, tmp_b_c)
+		case engine.FusedNewtonRefine:
+			if e.IsBTS {
+				y, _ = e.DoBootstrap(y, 2)
+				x, _ = e.DoBootstrap(x, y.Level())
+			}
 
-		tmp_b_c, _ = e.Mult(tmp_b_c, y)
-		y, _ = e.Sub(tmp_a_c, tmp_b_c)
+			y, _ = e.NewtonStep(x, y, float64(N))
+		case engine.GoldschmidtRefine:
+			if e.IsBTS && g.Level() < 4 {
+				y, _ = e.DoBootstrap(y, 5)
+				g, _ = e.Mult(x, y)
+			}
+
+			r, _ := e.Mult(g, y)
+			r, _ = e.SubConst(r, 0.5)
+			gr, _ := e.Mult(g, r)
+			hr, _ := e.Mult(y, r)
+
+			g, _ = e.Sub(g, gr)
+			y, _ = e.Sub(y, hr)
+		}
 		
 		elapsed := time.Since(start).Seconds()
 
@@ -80,7 +141,12 @@ func GetOptIter(e *engine.HEEngine, ct *engine.HEData, scaled_ct *engine.HEData,
 }
 
 
-func Optimizing(e *engine.HEEngine, d_min, d_max float64, i_max int, START, MIDDLE, STOP float64, N int, theta, delta float64) (map[int][]Rtuple) {
+// Optimizing searches, for every input level, the polynomial degree among d_min, d_min + d_step,
+// ..., d_max, the refinement, the iteration count and the pre-bootstrapping case of the inverse
+// square root, and returns the most accurate ("Basic") and the fastest ("Fast") configuration.
+// Every count of pieces evaluates a Piecewise candidate on [PiecewiseMin, 1] with the same
+// degree per piece against the single polynomials.
+func Optimizing(e *engine.HEEngine, d_min, d_max, d_step int, i_max int, START, MIDDLE, STOP float64, N int, theta, delta float64, init engine.InvSqrtInit, refines []engine.InvSqrtRefine, pieces []int) (map[int][]Rtuple) {
 	
 	B := STOP
 	
@@ -108,7 +174,7 @@ func Optimizing(e *engine.HEEngine, d_min, d_max float64, i_max int, START, MIDD
 		log.Println("*******************************************************************")
 		log.Println()
 
-		for d_e := d_min; d_e <= d_max; d_e++ {
+		for d_e := d_min; d_e <= d_max; d_e += d_step {
 
 			ct_base, _   := e.Encrypt(test, l)
 
@@ -116,7 +182,7 @@ func Optimizing(e *engine.HEEngine, d_min, d_max float64, i_max int, START, MIDD
 			ct, _ 		 := e.MultConst(ct_base, 1.0/2)
 
 			log.Println("===================================================================")
-			log.Println("Degree", math.Pow(2, d_e)-2)
+			log.Println("Degree", d_e, "Depth", engine.PolyDepth(d_e))
 			log.Println("===================================================================")
 			log.Println()
 
@@ -126,8 +192,11 @@ func Optimizing(e *engine.HEEngine, d_min, d_max float64, i_max int, START, MIDD
 				log.Println("No Pre-BTS")
 				log.Println("-------------------------------------------------------------------")
 
-				i, m, t := GetOptIter(e, ct, scaled_ct, invS, d_e, B, i_max, delta)
-				D[l] = append(D[l], Dtuple{d_e, 0, i, m, t})
+				for _, r := range refines {
+					log.Println("Refine", r)
+					i, m, t := GetOptIter(e, ct, scaled_ct, invS, d_e, B, i_max, delta, init, r)
+					D[l] = append(D[l], Dtuple{d_e, 0, i, m, t, r, 0})
+				}
 			}
 			if ct_base.Level() <= l_afterBTS -2 {
 
@@ -144,8 +213,24 @@ func Optimizing(e *engine.HEEngine, d_min, d_max float64, i_max int, START, MIDD
 				ct, _ 		 = e.MultConst(ct_base, 1.0/2)
 				elapsed := time.Since(start).Seconds()
 
-				i, m, t := GetOptIter(e, ct, scaled_ct, invS, d_e, B, i_max, delta)
-				D[l] = append(D[l], Dtuple{d_e, 1, i, m, t+elapsed})
+				for _, r := range refines {
+					log.Println("Refine", r)
+					i, m, t := GetOptIter(e, ct, scaled_ct, invS, d_e, B, i_max, delta, init, r)
+					D[l] = append(D[l], Dtuple{d_e, 1, i, m, t+elapsed, r, 0})
+				}
+			}
+			for _, P := range pieces {
+
+				log.Println("-------------------------------------------------------------------")
+				log.Println("Piecewise", P)
+				log.Println("-------------------------------------------------------------------")
+
+				ct_base, _ = e.Encrypt(test, l)
+				ct, _ = e.MultConst(ct_base, 1.0/2)
+
+				p := engine.Piecewise{Min: engine.PiecewiseMin, Pieces: P, Degree: d_e}
+				i, m, t := GetPiecewiseIter(e, ct, ct_base, invS, p, B, i_max, delta)
+				D[l] = append(D[l], Dtuple{d_e, 0, i, m, t, engine.NewtonRefine, P})
 			}
 
 		}
@@ -186,8 +271,8 @@ func Optimizing(e *engine.HEEngine, d_min, d_max float64, i_max int, START, MIDD
 		}
 
 		R[L] = []Rtuple{
-			{D: u1.D, C: u1.C, I: u1.I, M:u1.M, T:u1.T},
-			{D: u2.D, C: u2.C, I: u2.I, M:u2.M, T:u2.T},
+			{D: u1.D, C: u1.C, I: u1.I, M:u1.M, T:u1.T, R:u1.R, P:u1.P},
+			{D: u2.D, C: u2.C, I: u2.I, M:u2.M, T:u2.T, R:u2.R, P:u2.P},
 		}
 	}
 
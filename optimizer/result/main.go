@@ -18,6 +18,8 @@ type CaseInfo struct {
 	Iteration int     `json:"iteration"`
 	Time      float64 `json:"time"`
 	Mre       float64 `json:"mre"`
+	Refine    engine.InvSqrtRefine `json:"refine"`
+	Pieces    int     `json:"pieces,omitempty"`
 }
 
 func main() {
@@ -33,12 +35,13 @@ func main() {
 		STOP      = 100.0
 	)
 
-	engine := engine.NewHEEngine(config.NewParameters(16, 11, 50, true))
+	e := engine.NewHEEngine(config.NewParameters(16, 11, 50, true))
 
-	d_min, d_max := 4.0, 9.0
+	// Degrees 14, 30, ..., 510, including the 2^d - 2 of every depth d
+	d_min, d_max, d_step := 14, 510, 16
 	i_max := 15
 
-	R := optimizer.Optimizing(engine, d_min, d_max, i_max, START, MIDDLE, STOP, DATA_SIZE*2, 1.0, 1.0)
+	R := optimizer.Optimizing(e, d_min, d_max, d_step, i_max, START, MIDDLE, STOP, DATA_SIZE*2, 1.0, 1.0, engine.ChebyshevInit, []engine.InvSqrtRefine{engine.NewtonRefine, engine.GoldschmidtRefine, engine.FusedNewtonRefine}, []int{2, 4})
 
 	fmt.Println(R)
 
@@ -66,10 +69,12 @@ func main() {
 			caseName := "Basic"
 			jsonData[levelKey][caseName] = CaseInfo{
 				Case:      u1.C,
-				Degree:    int(u1.D),
+				Degree:    u1.D,
 				Iteration: u1.I,
 				Time:      u1.T,
 				Mre:       u1.M,
+				Refine:    u1.R,
+				Pieces:    u1.P,
 			}
 		}
 
@@ -78,10 +83,12 @@ func main() {
 			caseName := "Fast"
 			jsonData[levelKey][caseName] = CaseInfo{
 				Case:      u2.C,
-				Degree:    int(u2.D),
+				Degree:    u2.D,
 				Iteration: u2.I,
 				Time:      u2.T,
 				Mre:       u2.M,
+				Refine:    u2.R,
+				Pieces:    u2.P,
 			}
 		}
 	}
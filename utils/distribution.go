@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"math"
+)
+
+// PValueT returns the two-sided p-value P(|T| ≥ |t|) of Student's t distribution with df degrees
+// of freedom, i.e. the regularized incomplete beta function I_{df/(df+t²)}(df/2, 1/2).
+func PValueT(t, df float64) float64 {
+	return RegIncBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// PValueF returns the upper-tail p-value P(F ≥ f) of the F distribution with d1 and d2 degrees
+// of freedom, i.e. I_{d2/(d2+d1·f)}(d2/2, d1/2).
+func PValueF(f, d1, d2 float64) float64 {
+	return RegIncBeta(d2/2, d1/2, d2/(d2+d1*f))
+}
+
+// RegIncBeta returns the regularized incomplete beta function I_x(a, b) for a, b > 0 and
+// 0 ≤ x ≤ 1, evaluated with the continued fraction of Numerical Recipes (§6.4).
+func RegIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly for x < (a+1)/(a+b+2); use the symmetry
+	// I_x(a, b) = 1 - I_{1-x}(b, a) otherwise.
+	if x < (a+1)/(a+b+2) {
+		return front * betaContFrac(a, b, x) / a
+	}
+	return 1 - front*betaContFrac(b, a, 1-x)/b
+}
+
+// betaContFrac evaluates the continued fraction of the incomplete beta function with the
+// modified Lentz method.
+func betaContFrac(a, b, x float64) float64 {
+	const (
+		maxIter = 300
+		eps     = 1e-15
+		tiny    = 1e-300
+	)
+
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		m2 := float64(2 * m)
+		fm := float64(m)
+
+		// Even step
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
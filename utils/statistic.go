@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"math"
+	"slices"
 )
 
 func Inverse(data []float64) []float64 {
@@ -41,6 +42,11 @@ func StdDev(data []float64) float64 {
 	return math.Sqrt(Variance(data))
 }
 
+// StandardError returns the standard error of the mean σ/√n of the input slice.
+func StandardError(data []float64) float64 {
+	return StdDev(data) / math.Sqrt(float64(len(data)))
+}
+
 func ZScoreNorm(data []float64) []float64 {
 	mean := Mean(data)
 	invSigma := 1 / StdDev(data)
@@ -51,6 +57,34 @@ func ZScoreNorm(data []float64) []float64 {
 	return result
 }
 
+// MinMaxNorm scales data to [0, 1] as (x - min)/(max - min).
+func MinMaxNorm(data []float64) []float64 {
+	lo, hi := slices.Min(data), slices.Max(data)
+	result := make([]float64, len(data))
+	for i, v := range data {
+		result[i] = (v - lo) / (hi - lo)
+	}
+	return result
+}
+
+// Quantile returns the nearest-rank q-quantile x₍ₖ₎ of data, k = round(q·(n-1)) + 1.
+func Quantile(data []float64, q float64) float64 {
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+	return sorted[int(math.Round(q*float64(len(data)-1)))]
+}
+
+// RobustNorm scales data as (x - median)/(Q3 - Q1), with the quartiles of Quantile.
+func RobustNorm(data []float64) []float64 {
+	median := Quantile(data, 0.5)
+	iqr := Quantile(data, 0.75) - Quantile(data, 0.25)
+	result := make([]float64, len(data))
+	for i, v := range data {
+		result[i] = (v - median) / iqr
+	}
+	return result
+}
+
 // Covariance returns the covariance between two input slices x and y.
 func Covariance(x, y []float64) (float64, error) {
 	if len(x) != len(y) {
@@ -132,6 +166,53 @@ func Skewness(data []float64) (float64, float64, float64) {
 	return mean, stdDev, kurtosis
 }
 
+// SampleVariance returns the unbiased sample variance of the input slice, dividing by n-1.
+func SampleVariance(data []float64) float64 {
+	n := float64(len(data))
+	return Variance(data) * n / (n - 1)
+}
+
+// SampleSkewness returns the adjusted Fisher–Pearson skewness G₁ = √(n(n-1))/(n-2)·g₁ of the
+// input slice, where g₁ is the population skewness of Skewness.
+func SampleSkewness(data []float64) float64 {
+	n := float64(len(data))
+	_, _, g1 := Skewness(data)
+	return math.Sqrt(n*(n-1)) / (n - 2) * g1
+}
+
+// SampleKurtosis returns the adjusted excess kurtosis G₂ = (n-1)/((n-2)(n-3))·((n+1)·g₂ + 6) of
+// the input slice, where g₂ is the population excess kurtosis of Kurtosis.
+func SampleKurtosis(data []float64) float64 {
+	n := float64(len(data))
+	_, _, g2 := Kurtosis(data)
+	return (n - 1) / ((n - 2) * (n - 3)) * ((n+1)*g2 + 6)
+}
+
+// RawMoment returns the k-th raw moment E[X^k] of data.
+func RawMoment(data []float64, k int) float64 {
+	sum := 0.0
+	for _, v := range data {
+		sum += math.Pow(v, float64(k))
+	}
+	return sum / float64(len(data))
+}
+
+// CentralMoment returns the k-th central moment E[(X - μ)^k] of data.
+func CentralMoment(data []float64, k int) float64 {
+	mean := Mean(data)
+	sum := 0.0
+	for _, v := range data {
+		sum += math.Pow(v-mean, float64(k))
+	}
+	return sum / float64(len(data))
+}
+
+// StandardizedMoment returns the k-th standardized moment E[(X - μ)^k]/σ^k of data, with the
+// population standard deviation.
+func StandardizedMoment(data []float64, k int) float64 {
+	return CentralMoment(data, k) / math.Pow(math.Sqrt(CentralMoment(data, 2)), float64(k))
+}
+
 // CoeffVar calculates the coefficient of variation of a slice of float64 numbers.
 // Returns an error if the slice is empty or the mean is zero.
 func CoeffVar(data []float64) (mean float64, stdDev float64, coeffVar float64) {
@@ -162,3 +243,223 @@ func CoeffVar(data []float64) (mean float64, stdDev float64, coeffVar float64) {
 	coeffVar = stdDev / mean
 	return mean, stdDev, coeffVar
 }
+
+// TTest returns the two-sample t statistic of a against b and its degrees of freedom, using the
+// pooled sample variance (Student) or the Welch–Satterthwaite approximation (welch == true).
+func TTest(a, b []float64, welch bool) (t float64, df float64) {
+	na, nb := float64(len(a)), float64(len(b))
+	sa2 := Variance(a) * na / (na - 1)
+	sb2 := Variance(b) * nb / (nb - 1)
+
+	if welch {
+		va, vb := sa2/na, sb2/nb
+		t = (Mean(a) - Mean(b)) / math.Sqrt(va+vb)
+		df = (va + vb) * (va + vb) / (va*va/(na-1) + vb*vb/(nb-1))
+		return t, df
+	}
+
+	sp2 := ((na-1)*sa2 + (nb-1)*sb2) / (na + nb - 2)
+	t = (Mean(a) - Mean(b)) / math.Sqrt(sp2*(1/na+1/nb))
+	return t, na + nb - 2
+}
+
+// TTestOneSample returns the one-sample t statistic of data against mu0 and its degrees of freedom.
+func TTestOneSample(data []float64, mu0 float64) (t float64, df float64) {
+	n := float64(len(data))
+	s2 := Variance(data) * n / (n - 1)
+	return (Mean(data) - mu0) / math.Sqrt(s2/n), n - 1
+}
+
+// ChiSquare returns Pearson's chi-square statistic of independence between two categorical
+// columns, its degrees of freedom and Cramér's V = √(χ²/(n·(min(r, c)-1))).
+func ChiSquare(a, b []string) (chi2 float64, df int, cramersV float64) {
+	levelsA, colsA := OneHot(a)
+	levelsB, colsB := OneHot(b)
+	n := float64(len(a))
+
+	for i := range colsA {
+		rowTotal := 0.0
+		for _, v := range colsA[i] {
+			rowTotal += v
+		}
+		for j := range colsB {
+			colTotal, observed := 0.0, 0.0
+			for k := range colsB[j] {
+				colTotal += colsB[j][k]
+				observed += colsA[i][k] * colsB[j][k]
+			}
+			expected := rowTotal * colTotal / n
+			chi2 += (observed - expected) * (observed - expected) / expected
+		}
+	}
+
+	r, c := len(levelsA), len(levelsB)
+	df = (r - 1) * (c - 1)
+	cramersV = math.Sqrt(chi2 / (n * float64(min(r, c)-1)))
+	return chi2, df, cramersV
+}
+
+// ANOVA returns the one-way ANOVA F statistic of values over the groups given by labels, with its
+// between-group and within-group degrees of freedom.
+func ANOVA(values []float64, labels []string) (f float64, dfBetween, dfWithin int) {
+	_, masks := OneHot(labels)
+	mean := Mean(values)
+
+	ssb, ssw := 0.0, 0.0
+	for _, mask := range masks {
+		count, sum := 0.0, 0.0
+		for i, m := range mask {
+			count += m
+			sum += m * values[i]
+		}
+		groupMean := sum / count
+		ssb += count * (groupMean - mean) * (groupMean - mean)
+		for i, m := range mask {
+			ssw += m * (values[i] - groupMean) * (values[i] - groupMean)
+		}
+	}
+
+	dfBetween, dfWithin = len(masks)-1, len(values)-len(masks)
+	f = (ssb / float64(dfBetween)) / (ssw / float64(dfWithin))
+	return f, dfBetween, dfWithin
+}
+
+// LogisticRegression fits P(y = 1) = σ(bias + Σⱼ weights[j]·X[j]) with epochs steps of full-batch
+// gradient descent on the mean log-loss, starting from zero weights.
+func LogisticRegression(X [][]float64, y []float64, epochs int, rate float64) (weights []float64, bias float64) {
+	n := float64(len(y))
+	weights = make([]float64, len(X))
+	for range epochs {
+		grad := make([]float64, len(X))
+		gradBias := 0.0
+		for i := range y {
+			z := bias
+			for j := range X {
+				z += weights[j] * X[j][i]
+			}
+			residual := 1/(1+math.Exp(-z)) - y[i]
+			for j := range X {
+				grad[j] += residual * X[j][i]
+			}
+			gradBias += residual
+		}
+		for j := range weights {
+			weights[j] -= rate * grad[j] / n
+		}
+		bias -= rate * gradBias / n
+	}
+	return weights, bias
+}
+
+// RollingMean returns the mean of the trailing window data[i-w+1..i] at every position i, with
+// zero for the first w-1 positions.
+func RollingMean(data []float64, w int) []float64 {
+	result := make([]float64, len(data))
+	for i := w - 1; i < len(data); i++ {
+		result[i] = Mean(data[i-w+1 : i+1])
+	}
+	return result
+}
+
+// RollingVariance returns the population variance of the trailing window data[i-w+1..i] at every
+// position i, with zero for the first w-1 positions.
+func RollingVariance(data []float64, w int) []float64 {
+	result := make([]float64, len(data))
+	for i := w - 1; i < len(data); i++ {
+		result[i] = Variance(data[i-w+1 : i+1])
+	}
+	return result
+}
+
+// RollingZScore returns (x_i - μ_i)/σ_i with the mean and the population standard deviation of
+// the trailing window at every position i, with zero for the first w-1 positions.
+func RollingZScore(data []float64, w int) []float64 {
+	result := make([]float64, len(data))
+	for i := w - 1; i < len(data); i++ {
+		window := data[i-w+1 : i+1]
+		result[i] = (data[i] - Mean(window)) / math.Sqrt(Variance(window))
+	}
+	return result
+}
+
+// EWMA returns the exponentially weighted moving average s_i = α·x_i + (1-α)·s_{i-1}, s_0 = x_0.
+func EWMA(data []float64, alpha float64) []float64 {
+	result := make([]float64, len(data))
+	for i, v := range data {
+		if i == 0 {
+			result[i] = v
+		} else {
+			result[i] = alpha*v + (1-alpha)*result[i-1]
+		}
+	}
+	return result
+}
+
+// Autocorrelation returns the autocorrelation function r_k = c_k/c_0 of data for the lags
+// k = 1..maxLag, with the autocovariances c_k = (1/n)·Σ_{t≥k} (x_t - μ)(x_{t-k} - μ).
+func Autocorrelation(data []float64, maxLag int) []float64 {
+	mean := Mean(data)
+	cov := func(k int) float64 {
+		sum := 0.0
+		for t := k; t < len(data); t++ {
+			sum += (data[t] - mean) * (data[t-k] - mean)
+		}
+		return sum / float64(len(data))
+	}
+	c0 := cov(0)
+	acf := make([]float64, maxLag)
+	for k := range acf {
+		acf[k] = cov(k+1) / c0
+	}
+	return acf
+}
+
+// GroupSummary holds the statistics of one group computed by GroupBy.
+type GroupSummary struct {
+	Count       float64
+	Mean        float64
+	Variance    float64
+	Skewness    float64
+	Kurtosis    float64
+	Correlation float64
+}
+
+// GroupBy returns the count, mean, population variance, skewness and excess kurtosis of values
+// within every level of labels, in the order of OneHot, and the correlation with other unless it
+// is nil.
+func GroupBy(values, other []float64, labels []string) (levels []string, stats []GroupSummary) {
+	levels, columns := OneHot(labels)
+	stats = make([]GroupSummary, len(levels))
+	for g, mask := range columns {
+		var xs, ys []float64
+		for i, m := range mask {
+			if m == 1 {
+				xs = append(xs, values[i])
+				if other != nil {
+					ys = append(ys, other[i])
+				}
+			}
+		}
+		stats[g] = GroupSummary{Count: float64(len(xs)), Mean: Mean(xs), Variance: Variance(xs)}
+		_, _, stats[g].Skewness = Skewness(xs)
+		_, _, stats[g].Kurtosis = Kurtosis(xs)
+		if other != nil {
+			_, stats[g].Correlation, _ = Correlation(xs, ys)
+		}
+	}
+	return levels, stats
+}
+
+// OutlierStats returns the number of values of data with a population z-score |z| > k, and the
+// mean and population variance of the other values.
+func OutlierStats(data []float64, k float64) (count int, mean, variance float64) {
+	var inliers []float64
+	for i, z := range ZScoreNorm(data) {
+		if math.Abs(z) > k {
+			count++
+		} else {
+			inliers = append(inliers, data[i])
+		}
+	}
+	return count, Mean(inliers), Variance(inliers)
+}
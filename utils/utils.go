@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/rand/v2"
 	"os"
+	"sort"
 	"strconv"
 )
 
@@ -83,6 +84,8 @@ func IsPowerOfTwo(x float64) bool {
 	return frac == 0
 }
 
+// ReadCSV reads the numeric column index of a CSV file with a header row. yes/no values are
+// mapped to 1/0; any other non-numeric value is an error.
 func ReadCSV(fileName string, index int) ([]float64, error) {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -106,9 +109,59 @@ func ReadCSV(fileName string, index int) ([]float64, error) {
 					data = append(data, 1.0)
 				} else if v[index] == "no" {
 					data = append(data, 0.0)
+				} else {
+					return nil, fmt.Errorf("row %d: non-numeric value %q (use ReadCSVCategorical)", i, v[index])
 				}
 			}
 		}
 	}
 	return data, nil
 }
+
+// ReadCSVCategorical reads the column index of a CSV file with a header row as raw strings.
+func ReadCSVCategorical(fileName string, index int) ([]string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("file open failed: %w", err)
+	}
+	defer file.Close()
+	rdr := csv.NewReader(bufio.NewReader(file))
+
+	rows, err := rdr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("file read failed: %w", err)
+	}
+
+	data := []string{}
+	for i, v := range rows {
+		if i != 0 {
+			data = append(data, v[index])
+		}
+	}
+	return data, nil
+}
+
+// OneHot encodes a categorical column as one 0/1 indicator column per level.
+// The levels are returned in sorted order, and columns[k][i] is 1 iff values[i] == levels[k].
+func OneHot(values []string) (levels []string, columns [][]float64) {
+	index := map[string]int{}
+	for _, v := range values {
+		index[v] = 0
+	}
+	for v := range index {
+		levels = append(levels, v)
+	}
+	sort.Strings(levels)
+	for k, v := range levels {
+		index[v] = k
+	}
+
+	columns = make([][]float64, len(levels))
+	for k := range columns {
+		columns[k] = make([]float64, len(values))
+	}
+	for i, v := range values {
+		columns[index[v]][i] = 1
+	}
+	return levels, columns
+}